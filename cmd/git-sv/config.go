@@ -1,16 +1,28 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"net/url"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/bvieira/sv4git/sv"
 
+	"github.com/BurntSushi/toml"
 	"github.com/imdario/mergo"
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v3"
@@ -18,7 +30,12 @@ import (
 
 // EnvConfig env vars for cli configuration
 type EnvConfig struct {
-	Home string `envconfig:"SV4GIT_HOME" default:""`
+	Home      string `envconfig:"SV4GIT_HOME" default:""`
+	GitBinary string `envconfig:"SV4GIT_GIT_BINARY" default:"git"`
+	// config overrides, comma separated values, eg.: SV4GIT_TYPES=feat,fix,chore
+	Types      []string `envconfig:"SV4GIT_TYPES"`
+	Scopes     []string `envconfig:"SV4GIT_SCOPES"`
+	TagPattern string   `envconfig:"SV4GIT_TAG_PATTERN"`
 }
 
 func loadEnvConfig() EnvConfig {
@@ -30,33 +47,143 @@ func loadEnvConfig() EnvConfig {
 	return c
 }
 
+// applyEnvConfig override config values defined on env vars, env vars have precedence over config files.
+func applyEnvConfig(cfg *Config, env EnvConfig) {
+	if env.Types != nil {
+		cfg.CommitMessage.Types = env.Types
+	}
+	if env.Scopes != nil {
+		cfg.CommitMessage.Scope.Values = env.Scopes
+	}
+	if env.TagPattern != "" {
+		cfg.Tag.Pattern = env.TagPattern
+	}
+}
+
 // Config cli yaml config
 type Config struct {
 	Version       string                 `yaml:"version"`
+	Extends       string                 `yaml:"extends"`
 	Versioning    sv.VersioningConfig    `yaml:"versioning"`
 	Tag           sv.TagConfig           `yaml:"tag"`
 	ReleaseNotes  sv.ReleaseNotesConfig  `yaml:"release-notes"`
 	Branches      sv.BranchesConfig      `yaml:"branches"`
 	CommitMessage sv.CommitMessageConfig `yaml:"commit-message"`
+	Cache         sv.CacheConfig         `yaml:"cache"`
+}
+
+// gitBinaryFromArgs get git binary from --git-binary global flag, it's needed before parsing the cli, to load the repository config.
+func gitBinaryFromArgs(args []string, defaultValue string) string {
+	return globalFlagFromArgs(args, "git-binary", defaultValue)
+}
+
+// repoDirFromArgs get repository dir from --repo-path global flag, it's needed before parsing the cli, to load the repository config.
+func repoDirFromArgs(args []string, defaultValue string) string {
+	return globalFlagFromArgs(args, "repo-path", defaultValue)
+}
+
+func globalFlagFromArgs(args []string, name, defaultValue string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return defaultValue
+		case (arg == "--"+name || arg == "-"+name) && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, "--"+name+"="):
+			return strings.TrimPrefix(arg, "--"+name+"=")
+		case strings.HasPrefix(arg, "-"+name+"="):
+			return strings.TrimPrefix(arg, "-"+name+"=")
+		}
+	}
+	return defaultValue
+}
+
+// gitCommand create git command, running on dir if defined.
+func gitCommand(gitBinary, dir string, args ...string) *exec.Cmd {
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	return exec.Command(gitBinary, args...)
 }
 
-func getRepoPath() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+func getRepoPath(gitBinary, dir string) (string, error) {
+	cmd := gitCommand(gitBinary, dir, "rev-parse", "--show-toplevel")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", errors.New(string(out))
+		return "", commandErr(err, out)
 	}
 	return strings.TrimSpace(string(out)), nil
 }
 
+// getCacheDir get dir used by git log cache, if not defined on config, a dir inside git dir is used.
+func getCacheDir(gitBinary, dir string, cfg sv.CacheConfig) (string, error) {
+	if cfg.Dir != "" {
+		return filepath.Abs(cfg.Dir)
+	}
+	return getGitPath(gitBinary, dir, "sv4git-cache")
+}
+
+// getGitPath resolve path inside git dir, eg.: hooks, as an absolute path.
+func getGitPath(gitBinary, dir, name string) (string, error) {
+	cmd := gitCommand(gitBinary, dir, "rev-parse", "--git-path", name)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", commandErr(err, out)
+	}
+	path := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(path) { // git returns paths relative to -C dir
+		path = filepath.Join(dir, path)
+	}
+	return filepath.Abs(path)
+}
+
+// commandErr use command output as error message, if output is empty, err is used instead.
+func commandErr(err error, out []byte) error {
+	if len(strings.TrimSpace(string(out))) == 0 {
+		return err
+	}
+	return errors.New(string(out))
+}
+
+// supported config file formats.
+const (
+	yamlConfigFormat = "yaml"
+	tomlConfigFormat = "toml"
+	jsonConfigFormat = "json"
+)
+
+// configExtensions supported config file extensions, in lookup order.
+var configExtensions = []string{".yml", ".toml", ".json"}
+
+func configFormat(path string) string {
+	switch filepath.Ext(path) {
+	case ".toml":
+		return tomlConfigFormat
+	case ".json":
+		return jsonConfigFormat
+	default:
+		return yamlConfigFormat
+	}
+}
+
+// findConfig return the first config file found on dir using name and a supported extension, returns empty if not found.
+func findConfig(dir, name string) string {
+	for _, ext := range configExtensions {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
 func loadConfig(filepath string) (Config, error) {
 	content, rerr := ioutil.ReadFile(filepath)
 	if rerr != nil {
 		return Config{}, rerr
 	}
 
-	var cfg Config
-	cerr := yaml.Unmarshal(content, &cfg)
+	cfg, cerr := unmarshalConfig(content, configFormat(filepath))
 	if cerr != nil {
 		return Config{}, fmt.Errorf("could not parse config from path: %s, error: %v", filepath, cerr)
 	}
@@ -64,6 +191,325 @@ func loadConfig(filepath string) (Config, error) {
 	return cfg, nil
 }
 
+// unmarshalConfig parse config using format, toml and json are converted to yaml to reuse yaml keys.
+func unmarshalConfig(content []byte, format string) (Config, error) {
+	var cfg Config
+	if format == yamlConfigFormat {
+		err := yaml.Unmarshal(content, &cfg)
+		return cfg, err
+	}
+
+	values := make(map[string]interface{})
+	var err error
+	if format == tomlConfigFormat {
+		err = toml.Unmarshal(content, &values)
+	} else {
+		err = json.Unmarshal(content, &values)
+	}
+	if err != nil {
+		return Config{}, err
+	}
+
+	yamlContent, err := yaml.Marshal(values)
+	if err != nil {
+		return Config{}, err
+	}
+	err = yaml.Unmarshal(yamlContent, &cfg)
+	return cfg, err
+}
+
+// marshalConfig format config using format, toml and json use the same keys as yaml.
+func marshalConfig(cfg Config, format string) ([]byte, error) {
+	yamlContent, err := yaml.Marshal(&cfg)
+	if err != nil || format == yamlConfigFormat {
+		return yamlContent, err
+	}
+
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(yamlContent, &values); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case tomlConfigFormat:
+		var b bytes.Buffer
+		if err := toml.NewEncoder(&b).Encode(values); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	case jsonConfigFormat:
+		return json.MarshalIndent(values, "", "  ")
+	default:
+		return nil, fmt.Errorf("invalid format: %s, expected one of: %s, %s, %s", format, jsonConfigFormat, tomlConfigFormat, yamlConfigFormat)
+	}
+}
+
+// diffConfig list keys with different values on base and cfg, formatted as "key: base -> cfg", keys are yaml keys joined by "." and sorted.
+func diffConfig(base, cfg Config) ([]string, error) {
+	baseValues, err := flattenConfig(base)
+	if err != nil {
+		return nil, err
+	}
+	cfgValues, err := flattenConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{})
+	for k := range baseValues {
+		keys[k] = struct{}{}
+	}
+	for k := range cfgValues {
+		keys[k] = struct{}{}
+	}
+
+	var diff []string
+	for k := range keys {
+		if baseValues[k] != cfgValues[k] {
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", k, str(baseValues[k], "<undefined>"), str(cfgValues[k], "<undefined>")))
+		}
+	}
+	sort.Strings(diff)
+	return diff, nil
+}
+
+// flattenConfig map config yaml keys to json encoded values, nested keys are joined by ".", lists are kept as a single value.
+func flattenConfig(cfg Config) (map[string]string, error) {
+	content, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	flattenValues("", values, result)
+	return result, nil
+}
+
+func flattenValues(prefix string, value interface{}, result map[string]string) {
+	if m, ok := value.(map[string]interface{}); ok && len(m) > 0 {
+		for k, v := range m {
+			flattenValues(strings.TrimPrefix(prefix+"."+k, "."), v, result)
+		}
+		return
+	}
+	content, _ := json.Marshal(value)
+	result[prefix] = string(content)
+}
+
+// max number of nested extends, used to avoid cycles.
+const maxExtendsDepth = 10
+
+// extendConfig merge cfg into the config defined on its extends, extends can be a file path, relative to base, or an http(s) url.
+func extendConfig(cfg Config, base string) (Config, error) {
+	return extendConfigDepth(cfg, base, 0)
+}
+
+func extendConfigDepth(cfg Config, base string, depth int) (Config, error) {
+	if cfg.Extends == "" {
+		return cfg, nil
+	}
+	if depth >= maxExtendsDepth {
+		return Config{}, fmt.Errorf("could not extend config from: %s, max depth of %d extends reached", cfg.Extends, maxExtendsDepth)
+	}
+
+	location := resolveExtends(base, cfg.Extends)
+	content, err := readExtends(location)
+	if err != nil {
+		return Config{}, err
+	}
+
+	parent, perr := unmarshalConfig(content, configFormat(location))
+	if perr != nil {
+		return Config{}, fmt.Errorf("could not parse config from: %s, error: %v", location, perr)
+	}
+
+	parent, err = extendConfigDepth(parent, extendsBase(location), depth+1)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if merr := merge(&parent, cfg); merr != nil {
+		return Config{}, merr
+	}
+	return parent, nil
+}
+
+func isURL(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
+
+func resolveExtends(base, extends string) string {
+	if isURL(extends) {
+		return extends
+	}
+	if isURL(base) {
+		if b, err := url.Parse(base); err == nil {
+			if u, err := b.Parse(extends); err == nil {
+				return u.String()
+			}
+		}
+		return extends
+	}
+	if filepath.IsAbs(extends) {
+		return extends
+	}
+	return filepath.Join(base, extends)
+}
+
+func extendsBase(location string) string {
+	if isURL(location) {
+		return location
+	}
+	return filepath.Dir(location)
+}
+
+func readExtends(location string) ([]byte, error) {
+	if !isURL(location) {
+		content, err := ioutil.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("could not read extended config from path: %s, error: %v", location, err)
+		}
+		return content, nil
+	}
+
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch extended config from url: %s, error: %v", location, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch extended config from url: %s, status: %s", location, resp.Status)
+	}
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read extended config from url: %s, error: %v", location, err)
+	}
+	return content, nil
+}
+
+// withScopeValues return a copy of cfg with scopes from commit-message.scope.values-from appended to scope values.
+func withScopeValues(cfg Config, gitBinary, repoDir, repoPath string) (Config, error) {
+	cacheDir, err := getCacheDir(gitBinary, repoDir, cfg.Cache)
+	if err != nil {
+		return cfg, err
+	}
+	scopes, err := loadScopeValues(cfg.CommitMessage.Scope.ValuesFrom, repoPath, cacheDir)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.CommitMessage.Scope.Values = append(append([]string{}, cfg.CommitMessage.Scope.Values...), scopes...)
+	return cfg, nil
+}
+
+// loadScopeValues read scopes from source, a file path relative to repo or an url, one scope per line.
+// Content is cached on cacheDir and the cache is used if source can't be read, eg.: when offline.
+func loadScopeValues(source, repoPath, cacheDir string) ([]string, error) {
+	location := resolveExtends(repoPath, source)
+	sum := sha256.Sum256([]byte(location))
+	cacheFile := filepath.Join(cacheDir, "scopes-"+hex.EncodeToString(sum[:8]))
+
+	content, err := readScopesSource(location)
+	if err != nil {
+		cached, cerr := ioutil.ReadFile(cacheFile)
+		if cerr != nil {
+			return nil, err
+		}
+		warnStderr("could not read scopes from: %s, using cached values, error: %v", location, err)
+		return parseScopeValues(string(cached)), nil
+	}
+
+	if merr := os.MkdirAll(cacheDir, 0755); merr == nil {
+		_ = ioutil.WriteFile(cacheFile, content, 0644) // cache is best effort
+	}
+	return parseScopeValues(string(content)), nil
+}
+
+func readScopesSource(location string) ([]byte, error) {
+	if !isURL(location) {
+		content, err := ioutil.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("could not read scopes from path: %s, error: %v", location, err)
+		}
+		return content, nil
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch scopes from url: %s, error: %v", location, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch scopes from url: %s, status: %s", location, resp.Status)
+	}
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read scopes from url: %s, error: %v", location, err)
+	}
+	return content, nil
+}
+
+// parseScopeValues parse one scope per line, blank lines and lines starting with # are ignored.
+func parseScopeValues(content string) []string {
+	var scopes []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			scopes = append(scopes, line)
+		}
+	}
+	return scopes
+}
+
+func loadTemplateOutputFormatter(repoPath string, cfg sv.ReleaseNotesConfig) (sv.OutputFormatter, error) {
+	templatePath := cfg.Template
+	if !filepath.IsAbs(templatePath) {
+		templatePath = filepath.Join(repoPath, templatePath)
+	}
+
+	content, rerr := ioutil.ReadFile(templatePath)
+	if rerr != nil {
+		return nil, fmt.Errorf("could not read release notes template from path: %s, error: %v", templatePath, rerr)
+	}
+
+	formatter, terr := sv.NewTemplateOutputFormatter(cfg, string(content))
+	if terr != nil {
+		return nil, fmt.Errorf("could not parse release notes template from path: %s, error: %v", templatePath, terr)
+	}
+	return formatter, nil
+}
+
+// validateConfig check values that would only fail when used, eg.: regexes, so config errors are reported on load.
+func validateConfig(cfg Config) error {
+	for _, r := range cfg.Branches.SkipRegex {
+		if _, err := regexp.Compile(r); err != nil {
+			return fmt.Errorf("invalid branches.skip-regex: %s, message: %v", r, err)
+		}
+	}
+	if contains(sv.UnmatchedSectionKey, cfg.CommitMessage.Types) {
+		return fmt.Errorf("invalid commit-message.types: %s is reserved for non conventional commits on release notes", sv.UnmatchedSectionKey)
+	}
+	for _, r := range cfg.CommitMessage.Issue.Regex {
+		if _, err := regexp.Compile(r); err != nil {
+			return fmt.Errorf("invalid commit-message.issue.regex: %s, message: %v", r, err)
+		}
+	}
+	for _, u := range cfg.ReleaseNotes.IssueURLs {
+		if _, err := regexp.Compile(u.Regex); err != nil {
+			return fmt.Errorf("invalid release-notes.issue-urls regex: %s, message: %v", u.Regex, err)
+		}
+	}
+	return nil
+}
+
 func defaultConfig() Config {
 	skipDetached := false
 	return Config{
@@ -71,25 +517,31 @@ func defaultConfig() Config {
 		Versioning: sv.VersioningConfig{
 			UpdateMajor:   []string{},
 			UpdateMinor:   []string{"feat"},
-			UpdatePatch:   []string{"build", "ci", "chore", "docs", "fix", "perf", "refactor", "style", "test"},
+			UpdatePatch:   []string{"build", "ci", "chore", "docs", "fix", "perf", "refactor", "revert", "style", "test"},
 			IgnoreUnknown: false,
 		},
 		Tag:          sv.TagConfig{Pattern: "%d.%d.%d"},
-		ReleaseNotes: sv.ReleaseNotesConfig{Headers: map[string]string{"fix": "Bug Fixes", "feat": "Features", "breaking-change": "Breaking Changes"}},
+		ReleaseNotes: sv.ReleaseNotesConfig{Headers: map[string]string{"fix": "Bug Fixes", "feat": "Features", "breaking-change": "Breaking Changes"}, EmptyMessage: "No changes.", DateSource: sv.DateSourceNow},
 		Branches: sv.BranchesConfig{
 			PrefixRegex:  "([a-z]+\\/)?",
 			SuffixRegex:  "(-.*)?",
 			DisableIssue: false,
 			Skip:         []string{"master", "main", "developer"},
+			SkipRegex:    []string{},
 			SkipDetached: &skipDetached,
 		},
 		CommitMessage: sv.CommitMessageConfig{
-			Types: []string{"build", "ci", "chore", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"},
-			Scope: sv.CommitMessageScopeConfig{},
+			Types:            []string{"build", "ci", "chore", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"},
+			DeniedTypes:      []string{},
+			TypeDescriptions: map[string]string{},
+			Scope:            sv.CommitMessageScopeConfig{},
+			Body:             sv.CommitMessageBodyConfig{WrapWidth: 72},
 			Footer: map[string]sv.CommitMessageFooterConfig{
-				"issue": {Key: "jira", KeySynonyms: []string{"Jira", "JIRA"}},
+				"issue":           {Key: "jira", KeySynonyms: []string{"Jira", "JIRA"}},
+				"breaking-change": {Key: "BREAKING CHANGE", KeySynonyms: []string{"BREAKING-CHANGE"}},
 			},
-			Issue: sv.CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+			FooterValidation: sv.CommitMessageFooterValidationConfig{Tokens: []string{}},
+			Issue:            sv.CommitMessageIssueConfig{Regex: sv.StringList{"[A-Z]+-[0-9]+"}, Placement: sv.IssuePlacementFooter},
 		},
 	}
 }
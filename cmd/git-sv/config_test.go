@@ -1,6 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -46,3 +52,287 @@ func Test_merge(t *testing.T) {
 		})
 	}
 }
+
+func Test_extendConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "base.yml"), "commit-message:\n  types: [feat, fix]\n  issue:\n    regex: '[A-Z]+-[0-9]+'\n")
+	writeFile(t, filepath.Join(dir, "shared", "nested.yml"), "extends: ../base.yml\ntag:\n  pattern: v%d.%d.%d\n")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/base.yml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "commit-message:\n  types: [feat, fix]\n")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    Config
+		wantErr bool
+	}{
+		{"no extends", Config{Version: "1.0"}, Config{Version: "1.0"}, false},
+		{"extends file", Config{Extends: "base.yml"}, Config{Extends: "base.yml", CommitMessage: sv.CommitMessageConfig{Types: []string{"feat", "fix"}, Issue: sv.CommitMessageIssueConfig{Regex: sv.StringList{"[A-Z]+-[0-9]+"}}}}, false},
+		{"local overrides extends", Config{Extends: "base.yml", CommitMessage: sv.CommitMessageConfig{Types: []string{"docs"}}}, Config{Extends: "base.yml", CommitMessage: sv.CommitMessageConfig{Types: []string{"docs"}, Issue: sv.CommitMessageIssueConfig{Regex: sv.StringList{"[A-Z]+-[0-9]+"}}}}, false},
+		{"nested extends", Config{Extends: "shared/nested.yml"}, Config{Extends: "shared/nested.yml", Tag: sv.TagConfig{Pattern: "v%d.%d.%d"}, CommitMessage: sv.CommitMessageConfig{Types: []string{"feat", "fix"}, Issue: sv.CommitMessageIssueConfig{Regex: sv.StringList{"[A-Z]+-[0-9]+"}}}}, false},
+		{"extends url", Config{Extends: server.URL + "/base.yml"}, Config{Extends: server.URL + "/base.yml", CommitMessage: sv.CommitMessageConfig{Types: []string{"feat", "fix"}}}, false},
+		{"missing file", Config{Extends: "missing.yml"}, Config{}, true},
+		{"url not found", Config{Extends: server.URL + "/missing.yml"}, Config{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extendConfig(tt.cfg, dir)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("extendConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extendConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadScopeValues(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	writeFile(t, filepath.Join(dir, "scopes.txt"), "# services\napi\n\n  web  \n")
+
+	online := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !online {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "billing\nusers\n")
+	}))
+	defer server.Close()
+
+	got, err := loadScopeValues("scopes.txt", dir, cacheDir)
+	if err != nil || !reflect.DeepEqual(got, []string{"api", "web"}) {
+		t.Errorf("loadScopeValues() file = %v, %v, want [api web]", got, err)
+	}
+
+	got, err = loadScopeValues(server.URL+"/scopes.txt", dir, cacheDir)
+	if err != nil || !reflect.DeepEqual(got, []string{"billing", "users"}) {
+		t.Errorf("loadScopeValues() url = %v, %v, want [billing users]", got, err)
+	}
+
+	online = false
+	got, err = loadScopeValues(server.URL+"/scopes.txt", dir, cacheDir)
+	if err != nil || !reflect.DeepEqual(got, []string{"billing", "users"}) {
+		t.Errorf("loadScopeValues() cached = %v, %v, want [billing users]", got, err)
+	}
+
+	if _, err := loadScopeValues(server.URL+"/other.txt", dir, cacheDir); err == nil {
+		t.Errorf("loadScopeValues() without cache should fail")
+	}
+}
+
+func Test_withScopeValues(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "scopes.txt"), "api\nweb\n")
+	values := []string{"core"}
+	cfg := Config{CommitMessage: sv.CommitMessageConfig{Scope: sv.CommitMessageScopeConfig{Values: values, ValuesFrom: "scopes.txt"}}, Cache: sv.CacheConfig{Dir: filepath.Join(dir, "cache")}}
+
+	got, err := withScopeValues(cfg, "git", dir, dir)
+	if err != nil || !reflect.DeepEqual(got.CommitMessage.Scope.Values, []string{"core", "api", "web"}) {
+		t.Errorf("withScopeValues() = %v, %v, want [core api web]", got.CommitMessage.Scope.Values, err)
+	}
+	if !reflect.DeepEqual(cfg.CommitMessage.Scope.Values, []string{"core"}) {
+		t.Errorf("withScopeValues() changed original values to %v", cfg.CommitMessage.Scope.Values)
+	}
+
+	cfg.CommitMessage.Scope.ValuesFrom = "missing.txt"
+	if _, err := withScopeValues(cfg, "git", dir, dir); err == nil {
+		t.Errorf("withScopeValues() with missing source should fail")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_gitBinaryFromArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no flag", []string{"next-version"}, "git"},
+		{"flag with value", []string{"--git-binary", "/usr/local/bin/git", "next-version"}, "/usr/local/bin/git"},
+		{"flag with equal", []string{"--git-binary=/usr/local/bin/git", "next-version"}, "/usr/local/bin/git"},
+		{"single dash flag", []string{"-git-binary", "/usr/local/bin/git", "next-version"}, "/usr/local/bin/git"},
+		{"flag without value", []string{"--git-binary"}, "git"},
+		{"after args terminator", []string{"validate-message", "--", "--git-binary", "/usr/local/bin/git"}, "git"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gitBinaryFromArgs(tt.args, "git"); got != tt.want {
+				t.Errorf("gitBinaryFromArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoDirFromArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no flag", []string{"next-version"}, ""},
+		{"flag with value", []string{"--repo-path", "/tmp/repo", "next-version"}, "/tmp/repo"},
+		{"flag with equal", []string{"--repo-path=/tmp/repo", "next-version"}, "/tmp/repo"},
+		{"other flag", []string{"--git-binary", "/tmp/repo", "next-version"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repoDirFromArgs(tt.args, ""); got != tt.want {
+				t.Errorf("repoDirFromArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unmarshalConfig(t *testing.T) {
+	want := Config{Version: "1.0", Tag: sv.TagConfig{Pattern: "v%d.%d.%d"}, CommitMessage: sv.CommitMessageConfig{Types: []string{"feat", "fix"}}}
+
+	tests := []struct {
+		name    string
+		content string
+		format  string
+		want    Config
+		wantErr bool
+	}{
+		{"yaml", "version: \"1.0\"\ntag:\n  pattern: v%d.%d.%d\ncommit-message:\n  types: [feat, fix]\n", yamlConfigFormat, want, false},
+		{"toml", "version = \"1.0\"\n[tag]\npattern = \"v%d.%d.%d\"\n[commit-message]\ntypes = [\"feat\", \"fix\"]\n", tomlConfigFormat, want, false},
+		{"json", `{"version": "1.0", "tag": {"pattern": "v%d.%d.%d"}, "commit-message": {"types": ["feat", "fix"]}}`, jsonConfigFormat, want, false},
+		{"issue regex list", "commit-message:\n  issue:\n    regex: ['[A-Z]+-[0-9]+', '#?[0-9]+']\n", yamlConfigFormat, Config{CommitMessage: sv.CommitMessageConfig{Issue: sv.CommitMessageIssueConfig{Regex: sv.StringList{"[A-Z]+-[0-9]+", "#?[0-9]+"}}}}, false},
+		{"empty issue regex", "commit-message:\n  issue:\n    regex: ''\n", yamlConfigFormat, Config{CommitMessage: sv.CommitMessageConfig{Issue: sv.CommitMessageIssueConfig{Regex: sv.StringList{}}}}, false},
+		{"json issue regex list", `{"commit-message": {"issue": {"regex": ["[A-Z]+-[0-9]+", "#?[0-9]+"]}}}`, jsonConfigFormat, Config{CommitMessage: sv.CommitMessageConfig{Issue: sv.CommitMessageIssueConfig{Regex: sv.StringList{"[A-Z]+-[0-9]+", "#?[0-9]+"}}}}, false},
+		{"invalid toml", "version = ", tomlConfigFormat, Config{}, true},
+		{"invalid json", "{", jsonConfigFormat, Config{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unmarshalConfig([]byte(tt.content), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unmarshalConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unmarshalConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_marshalConfig(t *testing.T) {
+	cfg := defaultConfig()
+	for _, format := range []string{yamlConfigFormat, tomlConfigFormat, jsonConfigFormat} {
+		t.Run(format, func(t *testing.T) {
+			content, err := marshalConfig(cfg, format)
+			if err != nil {
+				t.Fatalf("marshalConfig() error = %v", err)
+			}
+			got, err := unmarshalConfig(content, format)
+			if err != nil {
+				t.Fatalf("unmarshalConfig() error = %v", err)
+			}
+			again, err := marshalConfig(got, format)
+			if err != nil {
+				t.Fatalf("marshalConfig() error = %v", err)
+			}
+			if string(again) != string(content) {
+				t.Errorf("marshalConfig() round trip = %s, want %s", again, content)
+			}
+		})
+	}
+}
+
+func Test_diffConfig(t *testing.T) {
+	changed := defaultConfig()
+	changed.Versioning.IgnoreUnknown = true
+	changed.ReleaseNotes.Headers = map[string]string{"feat": "New Features", "docs": "Docs"}
+	changed.Tag.Pattern = "v%d.%d.%d"
+
+	tests := []struct {
+		name string
+		base Config
+		cfg  Config
+		want []string
+	}{
+		{"same config", defaultConfig(), defaultConfig(), nil},
+		{"changed values", defaultConfig(), changed, []string{
+			`release-notes.headers.breaking-change: "Breaking Changes" -> <undefined>`,
+			`release-notes.headers.docs: <undefined> -> "Docs"`,
+			`release-notes.headers.feat: "Features" -> "New Features"`,
+			`release-notes.headers.fix: "Bug Fixes" -> <undefined>`,
+			`tag.pattern: "%d.%d.%d" -> "v%d.%d.%d"`,
+			`versioning.ignore-unknown: false -> true`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffConfig(tt.base, tt.cfg)
+			if err != nil {
+				t.Errorf("diffConfig() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_applyEnvConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		env  EnvConfig
+		want Config
+	}{
+		{"no overrides", EnvConfig{}, Config{CommitMessage: sv.CommitMessageConfig{Types: []string{"feat"}}, Tag: sv.TagConfig{Pattern: "%d.%d.%d"}}},
+		{"override types", EnvConfig{Types: []string{"feat", "wip"}}, Config{CommitMessage: sv.CommitMessageConfig{Types: []string{"feat", "wip"}}, Tag: sv.TagConfig{Pattern: "%d.%d.%d"}}},
+		{"override scopes and tag pattern", EnvConfig{Scopes: []string{"", "api"}, TagPattern: "v%d.%d.%d"}, Config{CommitMessage: sv.CommitMessageConfig{Types: []string{"feat"}, Scope: sv.CommitMessageScopeConfig{Values: []string{"", "api"}}}, Tag: sv.TagConfig{Pattern: "v%d.%d.%d"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{CommitMessage: sv.CommitMessageConfig{Types: []string{"feat"}}, Tag: sv.TagConfig{Pattern: "%d.%d.%d"}}
+			applyEnvConfig(&cfg, tt.env)
+			if !reflect.DeepEqual(cfg, tt.want) {
+				t.Errorf("applyEnvConfig() = %v, want %v", cfg, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"default config", defaultConfig(), false},
+		{"valid skip regex", Config{Branches: sv.BranchesConfig{SkipRegex: []string{"dependabot/.*", "renovate/.*"}}}, false},
+		{"invalid skip regex", Config{Branches: sv.BranchesConfig{SkipRegex: []string{"dependabot/(.*"}}}, true},
+		{"reserved commit type", Config{CommitMessage: sv.CommitMessageConfig{Types: []string{"feat", "other"}}}, true},
+		{"invalid issue url regex", Config{ReleaseNotes: sv.ReleaseNotesConfig{IssueURLs: []sv.IssueURLConfig{{Regex: "[A-Z]+-[0-9", URL: "https://jira.example.com/browse/%s"}}}}, true},
+		{"invalid issue regex", Config{CommitMessage: sv.CommitMessageConfig{Issue: sv.CommitMessageIssueConfig{Regex: sv.StringList{"[A-Z]+-[0-9"}}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateConfig(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,26 +1,77 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/bvieira/sv4git/sv"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/urfave/cli/v2"
-	"gopkg.in/yaml.v3"
 )
 
-func configDefaultHandler() func(c *cli.Context) error {
+// beforeHandler apply global flags before running commands.
+func beforeHandler(git *sv.GitImpl) func(c *cli.Context) error {
+	fetchTags := fetchTagsHandler(git)
+	return func(c *cli.Context) error {
+		if c.IsSet("merged-only") {
+			git.SetMergedOnly(c.Bool("merged-only"))
+		}
+		if c.Bool("no-cache") {
+			git.SetLogCache("")
+		}
+		if c.Bool("strict-tags") {
+			git.SetStrictTags(true)
+		}
+		return fetchTags(c)
+	}
+}
+
+func fetchTagsHandler(git sv.Git) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if !c.Bool("fetch") {
+			return nil
+		}
+		if err := git.FetchTags(c.String("remote")); err != nil {
+			return fmt.Errorf("error fetching tags from remote: %s, message: %v", c.String("remote"), err)
+		}
+		return nil
+	}
+}
+
+// logVersionDecision log commits and bump used to compute next version when verbose flag is enabled.
+func logVersionDecision(c *cli.Context, semverProcessor sv.SemVerCommitsProcessor, lastTag, branch string, paths []string, commits []sv.GitCommitLog, currentVer, nextVer semver.Version, updated bool) {
+	if !c.Bool("verbose") {
+		return
+	}
+	logVerbose(c, "last tag: %s, current version: %s", str(lastTag, "none"), versionString(currentVer))
+	logVerbose(c, "git log range: %s, paths: [%s]", logRangeDescription(lastTag, branch), strings.Join(paths, ", "))
+	classified := semverProcessor.Classify(currentVer, commits)
+	logVerbose(c, "parsed commits: %d, major: %d, minor: %d, patch: %d", len(commits), len(classified.Major), len(classified.Minor), len(classified.Patch))
+	logVerbose(c, "next version: %s, updated: %t", versionString(nextVer), updated)
+}
+
+func logRangeDescription(lastTag, branch string) string {
+	if lastTag == "" {
+		return str(branch, "HEAD")
+	}
+	return lastTag + ".." + str(branch, "HEAD")
+}
+
+func configDefaultHandler(format string) func(c *cli.Context) error {
 	cfg := defaultConfig()
 	return func(c *cli.Context) error {
-		content, err := yaml.Marshal(&cfg)
+		content, err := marshalConfig(cfg, str(c.String("format"), format))
 		if err != nil {
 			return err
 		}
@@ -29,9 +80,9 @@ func configDefaultHandler() func(c *cli.Context) error {
 	}
 }
 
-func configShowHandler(cfg Config) func(c *cli.Context) error {
+func configShowHandler(cfg Config, format string) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		content, err := yaml.Marshal(&cfg)
+		content, err := marshalConfig(cfg, str(c.String("format"), format))
 		if err != nil {
 			return err
 		}
@@ -40,6 +91,68 @@ func configShowHandler(cfg Config) func(c *cli.Context) error {
 	}
 }
 
+func configDiffHandler(cfg Config) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		base := defaultConfig()
+		if path := c.String("against"); path != "" {
+			against, err := loadConfig(path)
+			if err != nil {
+				return fmt.Errorf("error loading config: %s, message: %v", path, err)
+			}
+			if against, err = extendConfig(against, filepath.Dir(path)); err != nil {
+				return err
+			}
+			if err := merge(&base, against); err != nil {
+				return err
+			}
+		}
+
+		diff, err := diffConfig(base, cfg)
+		if err != nil {
+			return err
+		}
+		if len(diff) == 0 {
+			fmt.Println("no differences found")
+			return nil
+		}
+		for _, line := range diff {
+			fmt.Println(line)
+		}
+		return nil
+	}
+}
+
+func configTypesHandler(cfg Config) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		types := commitTypes(cfg.CommitMessage.AllowedTypes(), cfg.CommitMessage.TypeDescriptions)
+
+		if c.Bool("json") {
+			scopes := cfg.CommitMessage.Scope.Values
+			if scopes == nil {
+				scopes = []string{}
+			}
+			content, err := json.Marshal(struct {
+				Types  []commitType `json:"types"`
+				Scopes []string     `json:"scopes"`
+			}{Types: types, Scopes: scopes})
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(content))
+			return nil
+		}
+
+		for _, t := range types {
+			if t.Description == "" {
+				fmt.Println(t.Type)
+				continue
+			}
+			fmt.Printf("%s: %s\n", t.Type, t.Description)
+		}
+		return nil
+	}
+}
+
 func currentVersionHandler(git sv.Git) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
 		lastTag := git.LastTag()
@@ -53,7 +166,150 @@ func currentVersionHandler(git sv.Git) func(c *cli.Context) error {
 	}
 }
 
+// versionsHandler list every tag, newest first, with its parsed version, date and commit count since previous tag.
+func versionsHandler(git sv.Git) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		return writeVersions(os.Stdout, git, c.StringSlice("path"))
+	}
+}
+
+// writeVersions write tags table, newest first, tags created on same second are sorted by version.
+func writeVersions(out io.Writer, git sv.Git, paths []string) error {
+	tags, err := git.Tags()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].Date.Equal(tags[j].Date) { // tags created on same second, eg.: by scripts
+			vi, ierr := sv.ToVersion(tags[i].Name)
+			vj, jerr := sv.ToVersion(tags[j].Name)
+			return ierr == nil && jerr == nil && vi.GreaterThan(&vj)
+		}
+		return tags[i].Date.After(tags[j].Date)
+	})
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TAG\tVERSION\tDATE\tCOMMITS")
+	for i, tag := range tags {
+		previousTag := ""
+		if i+1 < len(tags) {
+			previousTag = tags[i+1].Name
+		}
+		commits, err := git.Log(sv.NewLogRange(sv.TagRange, previousTag, tag.Name, paths...))
+		if err != nil {
+			return fmt.Errorf("error getting git log from tag: %s, message: %v", tag.Name, err)
+		}
+
+		version := "-"
+		if v, err := sv.ToVersion(tag.Name); err == nil {
+			version = v.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", tag.Name, version, tag.Date.Format("2006-01-02"), len(commits))
+	}
+	return w.Flush()
+}
+
 func nextVersionHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) func(c *cli.Context) error {
+	return nextVersionOutputHandler(git, semverProcessor, func(prefix string, version semver.Version) string {
+		return versionString(version)
+	})
+}
+
+// nextTagHandler print tag name that would be created for next version, eg.: v1.2.3 or sub/v1.2.3 with module flag.
+func nextTagHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) func(c *cli.Context) error {
+	return nextVersionOutputHandler(git, semverProcessor, func(prefix string, version semver.Version) string {
+		return prefix + git.TagName(version)
+	})
+}
+
+func nextVersionOutputHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, output func(prefix string, version semver.Version) string) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		branch := c.String("branch")
+		module := strings.Trim(c.String("module"), "/")
+		paths := c.StringSlice("path")
+
+		var lastTag, prefix string
+		switch {
+		case c.Bool("release"):
+			if module != "" {
+				prefix = module + "/"
+				paths = append(paths, prefix)
+			}
+			lastTag = git.LastTagWithPrereleases(prefix, branch)
+		case module != "":
+			prefix = module + "/"
+			lastTag = git.LastPrefixedTag(prefix, branch)
+			paths = append(paths, prefix)
+		case branch != "":
+			lastTag = git.LastPrefixedTag("", branch)
+		default:
+			lastTag = git.LastTag()
+		}
+
+		currentVer, err := sv.ToVersion(strings.TrimPrefix(lastTag, prefix))
+		if err != nil {
+			return fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
+		}
+
+		commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, branch, paths...))
+		if err != nil {
+			return fmt.Errorf("error getting git log, message: %v", err)
+		}
+
+		nextVer, updated, err := nextVersion(semverProcessor, currentVer, commits, c.Bool("release"))
+		if err != nil {
+			return err
+		}
+		logVersionDecision(c, semverProcessor, lastTag, branch, paths, commits, currentVer, nextVer, updated)
+		if err := checkMaxVersion(nextVer, c.String("max-version")); err != nil {
+			return err
+		}
+		nextVer, err = setMetadata(nextVer, c.String("metadata"))
+		if err != nil {
+			return err
+		}
+		fmt.Println(output(prefix, nextVer))
+		if err := writeVersion(c.String("write-to"), output(prefix, nextVer)); err != nil {
+			return err
+		}
+
+		if !updated && c.Bool("fail-if-unchanged") {
+			return cli.Exit("no version update needed", noVersionUpdateExitCode)
+		}
+		return nil
+	}
+}
+
+// explicitVersion parse version defined by user, it should be greater than current version unless force is true.
+func explicitVersion(value string, currentVer semver.Version, force bool) (semver.Version, error) {
+	version, err := sv.ToVersion(value)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("error parsing version: %s, message: %v", value, err)
+	}
+	if version.Prerelease() != "" {
+		return semver.Version{}, fmt.Errorf("version: %s has a pre-release, which is not supported on tag pattern", value)
+	}
+	if !force && !version.GreaterThan(&currentVer) {
+		return semver.Version{}, fmt.Errorf("version: %s should be greater than current version: %s, use --force flag to tag it anyway", value, currentVer.String())
+	}
+	return version, nil
+}
+
+// nextVersion calculates next version based on commits, if release is true, current pre-release version is promoted instead.
+func nextVersion(semverProcessor sv.SemVerCommitsProcessor, currentVer semver.Version, commits []sv.GitCommitLog, release bool) (semver.Version, bool, error) {
+	if !release {
+		nextVer, updated := semverProcessor.NextVersion(currentVer, commits)
+		return nextVer, updated, nil
+	}
+
+	nextVer, updated := sv.PromoteVersion(currentVer)
+	if !updated {
+		return semver.Version{}, false, fmt.Errorf("current version: %s is not a pre-release, cannot use release flag", currentVer.String())
+	}
+	return nextVer, updated, nil
+}
+
+func explainHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
 		lastTag := git.LastTag()
 
@@ -62,15 +318,101 @@ func nextVersionHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) f
 			return fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
 		}
 
-		commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, ""))
+		commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, "", c.StringSlice("path")...))
 		if err != nil {
 			return fmt.Errorf("error getting git log, message: %v", err)
 		}
 
-		nextVer, _ := semverProcessor.NextVersion(currentVer, commits)
-		fmt.Printf("%d.%d.%d\n", nextVer.Major(), nextVer.Minor(), nextVer.Patch())
+		nextVer, updated := semverProcessor.NextVersion(currentVer, commits)
+		fmt.Printf("current version: %s\n", versionString(currentVer))
+		if !updated {
+			fmt.Println("no version update, there are no commits that update version since last tag")
+			return nil
+		}
+		fmt.Printf("next version: %s\n", versionString(nextVer))
+
+		classified := semverProcessor.Classify(currentVer, commits)
+		groups := []struct {
+			name    string
+			commits []sv.GitCommitLog
+		}{
+			{"major", classified.Major},
+			{"minor", classified.Minor},
+			{"patch", classified.Patch},
+		}
+		for _, group := range groups {
+			if len(group.commits) == 0 {
+				continue
+			}
+			fmt.Printf("\n%s, because of:\n", group.name)
+			for _, commit := range group.commits {
+				fmt.Printf("- %s %s\n", commit.Hash, commitHeader(commit.Message))
+			}
+		}
+		return nil
+	}
+}
+
+func commitHeader(msg sv.CommitMessage) string {
+	var header strings.Builder
+	header.WriteString(msg.Type)
+	if msg.Scope != "" {
+		header.WriteString("(" + msg.Scope + ")")
+	}
+	if msg.IsBreakingChange {
+		header.WriteString("!")
+	}
+	header.WriteString(": " + msg.Description)
+	return header.String()
+}
+
+func checkMaxVersion(version semver.Version, maxVersion string) error {
+	if maxVersion == "" {
+		return nil
+	}
+
+	constraint := maxVersion
+	if _, err := semver.StrictNewVersion(maxVersion); err == nil { // a plain version is used as upper limit
+		constraint = "<= " + maxVersion
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("error parsing max version: %s, message: %v", maxVersion, err)
+	}
+	if !c.Check(&version) {
+		return fmt.Errorf("next version: %s exceeds max version: %s", version.String(), maxVersion)
+	}
+	return nil
+}
+
+func setMetadata(version semver.Version, metadata string) (semver.Version, error) {
+	if metadata == "" {
+		return version, nil
+	}
+	v, err := version.SetMetadata(metadata)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("error setting build metadata: %s, message: %v", metadata, err)
+	}
+	return v, nil
+}
+
+// writeVersion write version or tag name to file, creating or overwriting it, if file is empty, nothing is written.
+func writeVersion(file string, version string) error {
+	if file == "" {
 		return nil
 	}
+	if err := ioutil.WriteFile(file, []byte(version+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing version to file: %s, message: %v", file, err)
+	}
+	return nil
+}
+
+func versionString(version semver.Version) string {
+	if version.Metadata() != "" {
+		return fmt.Sprintf("%d.%d.%d+%s", version.Major(), version.Minor(), version.Patch(), version.Metadata())
+	}
+	return fmt.Sprintf("%d.%d.%d", version.Major(), version.Minor(), version.Patch())
 }
 
 func commitLogHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) func(c *cli.Context) error {
@@ -81,24 +423,37 @@ func commitLogHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) fun
 		rangeFlag := c.String("r")
 		startFlag := c.String("s")
 		endFlag := c.String("e")
+		paths := c.StringSlice("path")
 		if tagFlag != "" && (rangeFlag != string(sv.TagRange) || startFlag != "" || endFlag != "") {
 			return fmt.Errorf("cannot define tag flag with range, start or end flags")
 		}
 
+		limit := c.Int("limit")
+		if limit < 0 {
+			return fmt.Errorf("invalid limit: %d, expected a positive number", limit)
+		}
+		dateType := c.String("date-type")
+		if dateType != "author" && dateType != "committer" {
+			return fmt.Errorf("invalid date type: %s, options: author, committer", dateType)
+		}
+
 		if tagFlag != "" {
-			commits, err = getTagCommits(git, tagFlag)
+			commits, err = getTagCommits(git, tagFlag, paths, limit)
 		} else {
-			r, rerr := logRange(git, rangeFlag, startFlag, endFlag)
+			r, rerr := logRange(git, rangeFlag, startFlag, endFlag, paths)
 			if rerr != nil {
 				return rerr
 			}
-			commits, err = git.Log(r)
+			commits, err = git.Log(r.WithLimit(limit))
 		}
 		if err != nil {
 			return fmt.Errorf("error getting git log, message: %v", err)
 		}
 
 		for _, commit := range commits {
+			if dateType == "committer" {
+				commit.Date = commit.CommitterDate
+			}
 			content, err := json.Marshal(commit)
 			if err != nil {
 				return err
@@ -109,33 +464,38 @@ func commitLogHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) fun
 	}
 }
 
-func getTagCommits(git sv.Git, tag string) ([]sv.GitCommitLog, error) {
+func getTagCommits(git sv.Git, tag string, paths []string, limit int) ([]sv.GitCommitLog, error) {
 	prev, _, err := getTags(git, tag)
 	if err != nil {
 		return nil, err
 	}
-	return git.Log(sv.NewLogRange(sv.TagRange, prev, tag))
+	return git.Log(sv.NewLogRange(sv.TagRange, prev, tag, paths...).WithLimit(limit))
 }
 
-func logRange(git sv.Git, rangeFlag, startFlag, endFlag string) (sv.LogRange, error) {
+func logRange(git sv.Git, rangeFlag, startFlag, endFlag string, paths []string) (sv.LogRange, error) {
 	switch rangeFlag {
 	case string(sv.TagRange):
-		return sv.NewLogRange(sv.TagRange, str(startFlag, git.LastTag()), endFlag), nil
+		return sv.NewLogRange(sv.TagRange, str(startFlag, git.LastTag()), endFlag, paths...), nil
 	case string(sv.DateRange):
-		return sv.NewLogRange(sv.DateRange, startFlag, endFlag), nil
+		return sv.NewLogRange(sv.DateRange, startFlag, endFlag, paths...), nil
 	case string(sv.HashRange):
-		return sv.NewLogRange(sv.HashRange, startFlag, endFlag), nil
+		return sv.NewLogRange(sv.HashRange, startFlag, endFlag, paths...), nil
 	default:
 		return sv.LogRange{}, fmt.Errorf("invalid range: %s, expected: %s, %s or %s", rangeFlag, sv.TagRange, sv.DateRange, sv.HashRange)
 	}
 }
 
-func commitNotesHandler(git sv.Git, rnProcessor sv.ReleaseNoteProcessor, outputFormatter sv.OutputFormatter) func(c *cli.Context) error {
+func commitNotesHandler(git sv.Git, rnProcessor sv.ReleaseNoteProcessor, outputFormatters map[string]sv.OutputFormatter) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
 		var date time.Time
 
+		outputFormatter, err := getOutputFormatter(outputFormatters, c.String("format"))
+		if err != nil {
+			return err
+		}
+
 		rangeFlag := c.String("r")
-		lr, err := logRange(git, rangeFlag, c.String("s"), c.String("e"))
+		lr, err := logRange(git, rangeFlag, c.String("s"), c.String("e"), c.StringSlice("path"))
 		if err != nil {
 			return err
 		}
@@ -155,47 +515,90 @@ func commitNotesHandler(git sv.Git, rnProcessor sv.ReleaseNoteProcessor, outputF
 	}
 }
 
-func releaseNotesHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, rnProcessor sv.ReleaseNoteProcessor, outputFormatter sv.OutputFormatter) func(c *cli.Context) error {
+func releaseNotesHandler(cfg Config, git sv.Git, semverProcessor sv.SemVerCommitsProcessor, rnProcessor sv.ReleaseNoteProcessor, outputFormatters map[string]sv.OutputFormatter) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
 		var commits []sv.GitCommitLog
 		var rnVersion semver.Version
 		var date time.Time
-		var err error
+		var tagMessage string
+
+		outputFormatter, err := getOutputFormatter(outputFormatters, c.String("format"))
+		if err != nil {
+			return err
+		}
 
 		if tag := c.String("t"); tag != "" {
-			rnVersion, date, commits, err = getTagVersionInfo(git, semverProcessor, tag)
+			var gitTag sv.GitTag
+			rnVersion, gitTag, commits, err = getTagVersionInfo(git, semverProcessor, tag, c.StringSlice("path"))
+			date, tagMessage = gitTag.Date, gitTag.Message
 		} else {
 			// TODO: should generate release notes if version was not updated?
-			rnVersion, _, date, commits, err = getNextVersionInfo(git, semverProcessor)
+			rnVersion, _, date, commits, err = getNextVersionInfo(git, semverProcessor, c.StringSlice("path"), dateSource(c, cfg))
 		}
 
 		if err != nil {
 			return err
 		}
 
+		if c.Bool("strict") {
+			if err := checkCommitTypes(cfg.CommitMessage.Types, commits); err != nil {
+				return err
+			}
+		}
+		if authors := c.StringSlice("author"); len(authors) > 0 {
+			commits = filterByAuthor(commits, authors)
+		}
+		if types := c.StringSlice("type"); len(types) > 0 {
+			commits = filterByType(commits, types)
+		}
+
 		releasenote := rnProcessor.Create(&rnVersion, date, commits)
+		releasenote.TagMessage = tagMessage
+		releasenote.Milestone = c.String("milestone")
+
+		if c.Bool("breaking-only") {
+			if len(releasenote.BreakingChanges.Messages) == 0 {
+				fmt.Println("no breaking changes")
+				return nil
+			}
+			releasenote = breakingChangesOnly(releasenote)
+		}
+
 		fmt.Println(outputFormatter.FormatReleaseNote(releasenote))
 		return nil
 	}
 }
 
-func getTagVersionInfo(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, tag string) (semver.Version, time.Time, []sv.GitCommitLog, error) {
+func getOutputFormatter(outputFormatters map[string]sv.OutputFormatter, format string) (sv.OutputFormatter, error) {
+	if formatter, exists := outputFormatters[format]; exists {
+		return formatter, nil
+	}
+
+	var formats []string
+	for f := range outputFormatters {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return nil, fmt.Errorf("invalid format: %s, expected one of: %s", format, strings.Join(formats, ", "))
+}
+
+func getTagVersionInfo(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, tag string, paths []string) (semver.Version, sv.GitTag, []sv.GitCommitLog, error) {
 	tagVersion, err := sv.ToVersion(tag)
 	if err != nil {
-		return semver.Version{}, time.Time{}, nil, fmt.Errorf("error parsing version: %s from tag, message: %v", tag, err)
+		return semver.Version{}, sv.GitTag{}, nil, fmt.Errorf("error parsing version: %s from tag, message: %v", tag, err)
 	}
 
 	previousTag, currentTag, err := getTags(git, tag)
 	if err != nil {
-		return semver.Version{}, time.Time{}, nil, fmt.Errorf("error listing tags, message: %v", err)
+		return semver.Version{}, sv.GitTag{}, nil, fmt.Errorf("error listing tags, message: %v", err)
 	}
 
-	commits, err := git.Log(sv.NewLogRange(sv.TagRange, previousTag, tag))
+	commits, err := git.Log(sv.NewLogRange(sv.TagRange, previousTag, tag, paths...))
 	if err != nil {
-		return semver.Version{}, time.Time{}, nil, fmt.Errorf("error getting git log from tag: %s, message: %v", tag, err)
+		return semver.Version{}, sv.GitTag{}, nil, fmt.Errorf("error getting git log from tag: %s, message: %v", tag, err)
 	}
 
-	return tagVersion, currentTag.Date, commits, nil
+	return tagVersion, currentTag, commits, nil
 }
 
 func getTags(git sv.Git, tag string) (string, sv.GitTag, error) {
@@ -225,7 +628,7 @@ func find(tag string, tags []sv.GitTag) int {
 	return -1
 }
 
-func getNextVersionInfo(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) (semver.Version, bool, time.Time, []sv.GitCommitLog, error) {
+func getNextVersionInfo(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, paths []string, dateSource string) (semver.Version, bool, time.Time, []sv.GitCommitLog, error) {
 	lastTag := git.LastTag()
 
 	currentVer, err := sv.ToVersion(lastTag)
@@ -233,47 +636,138 @@ func getNextVersionInfo(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) (
 		return semver.Version{}, false, time.Time{}, nil, fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
 	}
 
-	commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, ""))
+	commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, "", paths...))
 	if err != nil {
 		return semver.Version{}, false, time.Time{}, nil, fmt.Errorf("error getting git log, message: %v", err)
 	}
 
+	date, err := nextVersionDate(dateSource, commits)
+	if err != nil {
+		return semver.Version{}, false, time.Time{}, nil, err
+	}
+
 	version, updated := semverProcessor.NextVersion(currentVer, commits)
-	return version, updated, time.Now(), commits, nil
+	return version, updated, date, commits, nil
+}
+
+// nextVersionDate get unreleased version date, last-commit-date uses the most recent committer date, falling back to now if there are no commits.
+func nextVersionDate(dateSource string, commits []sv.GitCommitLog) (time.Time, error) {
+	switch dateSource {
+	case "", sv.DateSourceNow:
+		return time.Now(), nil
+	case sv.DateSourceLastCommitDate:
+		last := ""
+		for _, commit := range commits {
+			if commit.CommitterDate > last {
+				last = commit.CommitterDate
+			}
+		}
+		if last == "" {
+			return time.Now(), nil
+		}
+		date, err := time.Parse("2006-01-02", last)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error parsing commit date: %s, message: %v", last, err)
+		}
+		return date, nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid date source: %s, options: %s, %s", dateSource, sv.DateSourceNow, sv.DateSourceLastCommitDate)
+	}
+}
+
+// dateSource get date source from flag, or config if flag is not defined.
+func dateSource(c *cli.Context, cfg Config) string {
+	if c.IsSet("date-source") {
+		return c.String("date-source")
+	}
+	return cfg.ReleaseNotes.DateSource
 }
 
 func tagHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
+		if !c.Bool("allow-dirty") {
+			dirty, err := git.IsDirty()
+			if err != nil {
+				return fmt.Errorf("error checking working tree status, message: %v", err)
+			}
+			if dirty {
+				return fmt.Errorf("working tree has uncommitted changes, commit or stash them before tagging or use --allow-dirty flag")
+			}
+		}
+
+		branch := c.String("branch")
 		lastTag := git.LastTag()
+		switch {
+		case c.Bool("release"):
+			lastTag = git.LastTagWithPrereleases("", branch)
+		case branch != "":
+			lastTag = git.LastPrefixedTag("", branch)
+		}
 
 		currentVer, err := sv.ToVersion(lastTag)
 		if err != nil {
 			return fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
 		}
 
-		commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, ""))
+		commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, branch, c.StringSlice("path")...))
 		if err != nil {
 			return fmt.Errorf("error getting git log, message: %v", err)
 		}
 
-		nextVer, _ := semverProcessor.NextVersion(currentVer, commits)
-		fmt.Printf("%d.%d.%d\n", nextVer.Major(), nextVer.Minor(), nextVer.Patch())
+		var nextVer semver.Version
+		updated := true
+		if version := c.String("version"); version != "" {
+			if c.Bool("release") {
+				return fmt.Errorf("cannot define version flag with release flag")
+			}
+			nextVer, err = explicitVersion(version, currentVer, c.Bool("force"))
+		} else {
+			nextVer, updated, err = nextVersion(semverProcessor, currentVer, commits, c.Bool("release"))
+		}
+		if err != nil {
+			return err
+		}
+		logVersionDecision(c, semverProcessor, lastTag, branch, c.StringSlice("path"), commits, currentVer, nextVer, updated)
+
+		if err := checkMaxVersion(nextVer, c.String("max-version")); err != nil {
+			return err
+		}
+		nextVer, err = setMetadata(nextVer, c.String("metadata"))
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("yes") && isInteractive() {
+			confirmed, err := promptConfirm(fmt.Sprintf("create tag for version %s with %d commit(s)?", versionString(nextVer), len(commits)))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return fmt.Errorf("tag creation canceled")
+			}
+		}
+		fmt.Println(versionString(nextVer))
 
-		if err := git.Tag(nextVer); err != nil {
+		if err := git.Tag(nextVer, branch); err != nil {
 			return fmt.Errorf("error generating tag version: %s, message: %v", nextVer.String(), err)
 		}
-		return nil
+		return writeVersion(c.String("write-to"), versionString(nextVer))
 	}
 }
 
-func commitHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
+// commitHandler create a commit using prompts, if recentTypesFile is defined, recently used types are listed first.
+func commitHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor, recentTypesFile string) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		ctype, err := promptType(cfg.CommitMessage.Types)
+		types := orderTypes(cfg.CommitMessage.AllowedTypes(), cfg.CommitMessage.Prompt.TypeOrder)
+		if recentTypesFile != "" {
+			types = orderTypes(types, loadRecentTypes(recentTypesFile))
+		}
+		ctype, err := promptType(types, cfg.CommitMessage.TypeDescriptions)
 		if err != nil {
 			return err
 		}
 
-		scope, err := promptScope(cfg.CommitMessage.Scope.Values)
+		scope, err := promptScope(cfg.CommitMessage.Scope.Values, cfg.CommitMessage.Scope.Required)
 		if err != nil {
 			return err
 		}
@@ -302,8 +796,8 @@ func commitHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor)
 		}
 
 		var issue string
-		if cfg.CommitMessage.IssueFooterConfig().Key != "" && cfg.CommitMessage.Issue.Regex != "" {
-			issue, err = promptIssueID("issue id", cfg.CommitMessage.Issue.Regex, branchIssue)
+		if (cfg.CommitMessage.IssueFooterConfig().Key != "" || cfg.CommitMessage.Issue.IsSubjectSuffix()) && len(cfg.CommitMessage.Issue.Regex) > 0 {
+			issue, err = promptIssueID("issue id", strings.Join(cfg.CommitMessage.Issue.Regex, "|"), branchIssue)
 			if err != nil {
 				return err
 			}
@@ -323,69 +817,540 @@ func commitHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor)
 
 		header, body, footer := messageProcessor.Format(sv.NewCommitMessage(ctype.Type, scope, subject, fullBody.String(), issue, breakingChanges))
 
-		err = git.Commit(header, body, footer)
+		sign := git.ConfigBool("commit.gpgsign")
+		if c.IsSet("sign") {
+			sign = c.Bool("sign")
+		}
+		err = git.Commit(header, body, footer, sign)
+		if err == sv.ErrCommitSigning {
+			return fmt.Errorf("error executing signed git commit, nothing was committed, check gpg config (user.signingkey, gpg.program) or use --sign=false, message: %v", err)
+		}
 		if err != nil {
 			return fmt.Errorf("error executing git commit, message: %v", err)
 		}
+		if recentTypesFile != "" {
+			if err := saveRecentType(recentTypesFile, ctype.Type); err != nil {
+				warn("could not save recent commit types on: %s, error: %v", recentTypesFile, err)
+			}
+		}
 		return nil
 	}
 }
 
-func changelogHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, rnProcessor sv.ReleaseNoteProcessor, formatter sv.OutputFormatter) func(c *cli.Context) error {
-	return func(c *cli.Context) error {
-		tags, err := git.Tags()
-		if err != nil {
-			return err
-		}
-		sort.Slice(tags, func(i, j int) bool {
-			return tags[i].Date.After(tags[j].Date)
-		})
-
-		var releaseNotes []sv.ReleaseNote
-
-		size := c.Int("size")
-		all := c.Bool("all")
-		addNextVersion := c.Bool("add-next-version")
-
-		if addNextVersion {
-			rnVersion, updated, date, commits, uerr := getNextVersionInfo(git, semverProcessor)
-			if uerr != nil {
-				return uerr
-			}
-			if updated {
-				releaseNotes = append(releaseNotes, rnProcessor.Create(&rnVersion, date, commits))
+// filterByAuthor keep only commits whose author name or email contains any of authors, ignoring case.
+func filterByAuthor(commits []sv.GitCommitLog, authors []string) []sv.GitCommitLog {
+	var filtered []sv.GitCommitLog
+	for _, commit := range commits {
+		name, email := strings.ToLower(commit.AuthorName), strings.ToLower(commit.AuthorEmail)
+		for _, author := range authors {
+			author = strings.ToLower(author)
+			if strings.Contains(name, author) || strings.Contains(email, author) {
+				filtered = append(filtered, commit)
+				break
 			}
 		}
-		for i, tag := range tags {
-			if !all && i >= size {
+	}
+	return filtered
+}
+
+// filterByType keep only commits whose type is one of types, breaking changes from other types are also removed.
+func filterByType(commits []sv.GitCommitLog, types []string) []sv.GitCommitLog {
+	var filtered []sv.GitCommitLog
+	for _, commit := range commits {
+		for _, t := range types {
+			if commit.Message.Type == t {
+				filtered = append(filtered, commit)
 				break
 			}
+		}
+	}
+	return filtered
+}
 
-			previousTag := ""
-			if i+1 < len(tags) {
-				previousTag = tags[i+1].Name
+// changelogFilter filter commits by --type and --grep flags, grep is matched against commit header and body, case-insensitive unless --grep-case-sensitive is used.
+func changelogFilter(c *cli.Context) (func([]sv.GitCommitLog) []sv.GitCommitLog, error) {
+	types := c.StringSlice("type")
+	var grep *regexp.Regexp
+	if pattern := c.String("grep"); pattern != "" {
+		if !c.Bool("grep-case-sensitive") {
+			pattern = "(?i)" + pattern
+		}
+		var err error
+		if grep, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid grep pattern: %s, message: %v", c.String("grep"), err)
+		}
+	}
+
+	return func(commits []sv.GitCommitLog) []sv.GitCommitLog {
+		if len(types) > 0 {
+			commits = filterByType(commits, types)
+		}
+		if grep != nil {
+			commits = filterByGrep(commits, grep)
+		}
+		return commits
+	}, nil
+}
+
+// filterByGrep keep only commits whose header or body matches regex.
+func filterByGrep(commits []sv.GitCommitLog, regex *regexp.Regexp) []sv.GitCommitLog {
+	var filtered []sv.GitCommitLog
+	for _, commit := range commits {
+		if regex.MatchString(commitText(commit.Message)) {
+			filtered = append(filtered, commit)
+		}
+	}
+	return filtered
+}
+
+// commitText rebuild commit header and body, eg.: "feat(scope): description\n\nbody".
+func commitText(msg sv.CommitMessage) string {
+	header := msg.Description
+	if msg.Type != "" {
+		header = commitHeader(msg)
+	}
+	if msg.PullRequest != "" {
+		header += " (#" + msg.PullRequest + ")"
+	}
+	return header + "\n\n" + msg.Body
+}
+
+// breakingChangesOnly remove every section from release note except breaking changes.
+func breakingChangesOnly(releasenote sv.ReleaseNote) sv.ReleaseNote {
+	releasenote.Sections = map[string]sv.ReleaseNoteSection{}
+	releasenote.Summary = nil
+	return releasenote
+}
+
+func changelogHandler(cfg Config, git sv.Git, semverProcessor sv.SemVerCommitsProcessor, rnProcessor sv.ReleaseNoteProcessor, outputFormatters map[string]sv.OutputFormatter) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		formatter, err := getOutputFormatter(outputFormatters, c.String("format"))
+		if err != nil {
+			return err
+		}
+		if c.Bool("no-header") {
+			if c.String("format") != tsvFormat {
+				return fmt.Errorf("no-header flag is only supported with format: %s", tsvFormat)
+			}
+			formatter = sv.NewTSVOutputFormatter(false)
+		}
+
+		var releaseNotes []sv.ReleaseNote
+
+		size := c.Int("size")
+		all := c.Bool("all")
+		addNextVersion := c.Bool("add-next-version")
+		addUnreleased := c.Bool("add-unreleased")
+		strict := c.Bool("strict")
+		paths := c.StringSlice("path")
+
+		if addNextVersion && addUnreleased {
+			return fmt.Errorf("cannot define add-next-version flag with add-unreleased flag")
+		}
+		filter, err := changelogFilter(c)
+		if err != nil {
+			return err
+		}
+		if c.Bool("prepend") {
+			if c.String("output") == "" {
+				return fmt.Errorf("prepend flag requires output flag")
+			}
+			if format := c.String("format"); format == jsonFormat || format == atomFormat || format == tsvFormat {
+				return fmt.Errorf("prepend flag is not supported with format: %s", format)
+			}
+			if cfg.ReleaseNotes.TOC {
+				return fmt.Errorf("prepend flag is not supported with release-notes.toc config")
 			}
+			size, all = 1, false
+		}
+		if c.String("output") != "" && c.String("split-output") != "" {
+			return fmt.Errorf("cannot define output flag with split-output flag")
+		}
+
+		if groupBy := c.String("group-by"); groupBy != groupByTag {
+			if groupBy != groupByDay {
+				return fmt.Errorf("invalid group-by: %s, use: %s or %s", groupBy, groupByTag, groupByDay)
+			}
+			if addNextVersion || addUnreleased || c.String("start") != "" || c.String("end") != "" || c.Bool("prepend") || c.String("split-output") != "" {
+				return fmt.Errorf("cannot define group-by day with add-next-version, add-unreleased, start, end, prepend or split-output flags")
+			}
+			releaseNotes, commits, err := dailyReleaseNotes(cfg, git, rnProcessor, filter, paths, size, all, strict)
+			if err != nil {
+				return err
+			}
+			logSummary("changelog: %d days, %d commits", len(releaseNotes), commits)
+			return printChangelog(c, formatter, releaseNotes)
+		}
 
-			commits, err := git.Log(sv.NewLogRange(sv.TagRange, previousTag, tag.Name))
+		if start, end := c.String("start"), c.String("end"); start != "" || end != "" {
+			if addNextVersion || addUnreleased {
+				return fmt.Errorf("cannot define start or end flags with add-next-version or add-unreleased flags")
+			}
+			commits, err := git.Log(sv.NewLogRange(sv.HashRange, start, end, paths...))
+			if err != nil {
+				return fmt.Errorf("error getting git log from range: %s..%s, message: %v", start, str(end, "HEAD"), err)
+			}
+			if strict {
+				if err := checkCommitTypes(cfg.CommitMessage.Types, commits); err != nil {
+					return err
+				}
+			}
+			commits = filter(commits)
+			var date time.Time
+			if len(commits) > 0 {
+				date, _ = time.Parse("2006-01-02", commits[0].Date)
+			}
+			releaseNotes = append(releaseNotes, rnProcessor.Create(nil, date, commits))
+			logSummary("changelog: %d releases, %d commits", len(releaseNotes), len(commits))
+			return printChangelog(c, formatter, releaseNotes)
+		}
+
+		tags, err := git.Tags()
+		if err != nil {
+			return err
+		}
+		if err := sortTags(tags, c.String("sort")); err != nil {
+			return err
+		}
+		if !c.Bool("strict-tags") {
+			tags = withoutInvalidTags(tags)
+		}
+
+		totalCommits := 0
+		if addNextVersion {
+			rnVersion, updated, date, commits, uerr := getNextVersionInfo(git, semverProcessor, paths, dateSource(c, cfg))
+			if uerr != nil {
+				return uerr
+			}
+			if strict {
+				if err := checkCommitTypes(cfg.CommitMessage.Types, commits); err != nil {
+					return err
+				}
+			}
+			if updated {
+				commits = filter(commits)
+				releaseNotes = append(releaseNotes, rnProcessor.Create(&rnVersion, date, commits))
+				totalCommits += len(commits)
+			}
+		}
+		if addUnreleased {
+			commits, uerr := git.Log(sv.NewLogRange(sv.TagRange, git.LastTag(), "", paths...))
+			if uerr != nil {
+				return fmt.Errorf("error getting git log, message: %v", uerr)
+			}
+			if strict {
+				if err := checkCommitTypes(cfg.CommitMessage.Types, commits); err != nil {
+					return err
+				}
+			}
+			if commits = filter(commits); len(commits) > 0 {
+				releaseNotes = append(releaseNotes, rnProcessor.Create(nil, time.Time{}, commits))
+				totalCommits += len(commits)
+			}
+		}
+		total := len(tags)
+		if !all && size < total {
+			total = size
+		}
+		for i, tag := range tags {
+			if !all && i >= size {
+				break
+			}
+			logProgress("processing tag %d/%d", i+1, total)
+
+			previousTag := ""
+			if i+1 < len(tags) {
+				previousTag = tags[i+1].Name
+			}
+
+			commits, err := git.Log(sv.NewLogRange(sv.TagRange, previousTag, tag.Name, paths...))
 			if err != nil {
 				return fmt.Errorf("error getting git log from tag: %s, message: %v", tag.Name, err)
 			}
 
+			if strict {
+				if err := checkCommitTypes(cfg.CommitMessage.Types, commits); err != nil {
+					return fmt.Errorf("error on tag: %s, %v", tag.Name, err)
+				}
+			}
+			commits = filter(commits)
+
 			currentVer, err := sv.ToVersion(tag.Name)
 			if err != nil {
 				return fmt.Errorf("error parsing version: %s from git tag, message: %v", tag.Name, err)
 			}
-			releaseNotes = append(releaseNotes, rnProcessor.Create(&currentVer, tag.Date, commits))
+			releasenote := rnProcessor.Create(&currentVer, tag.Date, commits)
+			releasenote.TagMessage = tag.Message
+			releaseNotes = append(releaseNotes, releasenote)
+			totalCommits += len(commits)
+		}
+
+		logSummary("changelog: %d releases, %d commits", len(releaseNotes), totalCommits)
+		return printChangelog(c, formatter, releaseNotes)
+	}
+}
+
+// sortTags sort tags newest first, by date or semver, tags that aren't valid versions are moved to the end when sorting by semver.
+func sortTags(tags []sv.GitTag, by string) error {
+	switch by {
+	case tagSortDate:
+		sort.Slice(tags, func(i, j int) bool {
+			return tags[i].Date.After(tags[j].Date)
+		})
+	case tagSortSemver:
+		sort.SliceStable(tags, func(i, j int) bool {
+			vi, ierr := sv.ToVersion(tags[i].Name)
+			vj, jerr := sv.ToVersion(tags[j].Name)
+			if ierr != nil || jerr != nil {
+				return ierr == nil && jerr != nil
+			}
+			return vi.GreaterThan(&vj)
+		})
+	default:
+		return fmt.Errorf("invalid sort: %s, use: %s or %s", by, tagSortDate, tagSortSemver)
+	}
+	return nil
+}
+
+// dailyReleaseNotes group commits by author date, newest day first, each day is a release note without version labeled with its commit count.
+func dailyReleaseNotes(cfg Config, git sv.Git, rnProcessor sv.ReleaseNoteProcessor, filter func([]sv.GitCommitLog) []sv.GitCommitLog, paths []string, size int, all, strict bool) ([]sv.ReleaseNote, int, error) {
+	commits, err := git.Log(sv.NewLogRange(sv.DateRange, "", "", paths...))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting git log, message: %v", err)
+	}
+	if strict {
+		if err := checkCommitTypes(cfg.CommitMessage.Types, commits); err != nil {
+			return nil, 0, err
 		}
+	}
+	commits = filter(commits)
 
-		fmt.Println(formatter.FormatChangelog(releaseNotes))
+	var days []string
+	commitsByDay := make(map[string][]sv.GitCommitLog)
+	for _, commit := range commits {
+		if _, exists := commitsByDay[commit.Date]; !exists {
+			days = append(days, commit.Date)
+		}
+		commitsByDay[commit.Date] = append(commitsByDay[commit.Date], commit)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(days)))
+	if !all && size < len(days) {
+		days = days[:size]
+	}
 
+	total := 0
+	releaseNotes := make([]sv.ReleaseNote, 0, len(days))
+	for _, day := range days {
+		date, _ := time.Parse("2006-01-02", day)
+		dayCommits := commitsByDay[day]
+		releaseNote := rnProcessor.Create(nil, date, dayCommits)
+		releaseNote.Label = fmt.Sprintf("%d commit%s", len(dayCommits), plural(len(dayCommits)))
+		releaseNotes = append(releaseNotes, releaseNote)
+		total += len(dayCommits)
+	}
+	return releaseNotes, total, nil
+}
+
+func plural(count int) string {
+	if count == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// printChangelog print changelog or write it to split-output dir, one file per release note.
+func printChangelog(c *cli.Context, formatter sv.OutputFormatter, releaseNotes []sv.ReleaseNote) error {
+	if dir := c.String("split-output"); dir != "" {
+		return writeReleaseNotes(dir, formatExtension(c.String("format")), formatter, releaseNotes, c.Bool("force"))
+	}
+	if output := c.String("output"); output != "" {
+		if c.Bool("prepend") {
+			return prependChangelog(output, formatter, releaseNotes)
+		}
+		if err := ioutil.WriteFile(output, []byte(formatter.FormatChangelog(releaseNotes)), 0644); err != nil {
+			return fmt.Errorf("error writing changelog to file: %s, message: %v", output, err)
+		}
 		return nil
 	}
+
+	fmt.Println(formatter.FormatChangelog(releaseNotes))
+	return nil
+}
+
+func formatExtension(format string) string {
+	switch format {
+	case htmlFormat:
+		return "html"
+	case adocFormat:
+		return "adoc"
+	case jsonFormat:
+		return "json"
+	case slackFormat:
+		return "txt"
+	case atomFormat:
+		return "xml"
+	case tsvFormat:
+		return "tsv"
+	default:
+		return "md"
+	}
 }
 
-func validateCommitMessageHandler(git sv.Git, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
+// writeReleaseNotes write each release note on its own file named by version, existing files are only overwritten if force is true.
+func writeReleaseNotes(dir, extension string, formatter sv.OutputFormatter, releaseNotes []sv.ReleaseNote, force bool) error {
+	files := make([]string, len(releaseNotes))
+	for i, releaseNote := range releaseNotes {
+		name := "unreleased"
+		if releaseNote.Version != nil {
+			name = releaseNote.Version.String()
+		}
+		files[i] = filepath.Join(dir, name+"."+extension)
+
+		if _, err := os.Stat(files[i]); err == nil && !force {
+			return fmt.Errorf("file: %s already exists, use --force flag to overwrite it", files[i])
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating output dir: %s, message: %v", dir, err)
+	}
+
+	for i, releaseNote := range releaseNotes {
+		if err := ioutil.WriteFile(files[i], []byte(formatter.FormatReleaseNote(releaseNote)), 0644); err != nil {
+			return fmt.Errorf("error writing release note to file: %s, message: %v", files[i], err)
+		}
+		fmt.Println(files[i])
+	}
+	return nil
+}
+
+// withoutInvalidTags remove tags that aren't valid versions, eg.: latest, warning about each one.
+func withoutInvalidTags(tags []sv.GitTag) []sv.GitTag {
+	var result []sv.GitTag
+	for _, tag := range tags {
+		if _, err := sv.ToVersion(tag.Name); err != nil {
+			warnSkippedTag(tag.Name)
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// prependChangelog insert newest release note after changelog header, keeping existing content unchanged.
+// If file doesn't exist, a changelog with only the newest release note is created, if release is already there, file is not changed.
+func prependChangelog(file string, formatter sv.OutputFormatter, releaseNotes []sv.ReleaseNote) error {
+	if len(releaseNotes) == 0 {
+		warn("no release notes to prepend on %s", file)
+		return nil
+	}
+	newest := releaseNotes[0]
+	single := formatter.FormatChangelog([]sv.ReleaseNote{newest})
+
+	existing, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		existing = nil
+	} else if err != nil {
+		return fmt.Errorf("error reading changelog file: %s, message: %v", file, err)
+	}
+	if strings.TrimSpace(string(existing)) == "" {
+		if err := ioutil.WriteFile(file, []byte(single), 0644); err != nil {
+			return fmt.Errorf("error writing changelog to file: %s, message: %v", file, err)
+		}
+		return nil
+	}
+
+	header, entry := changelogEntry(formatter.FormatChangelog(nil), single)
+	if !strings.HasPrefix(string(existing), header) {
+		return fmt.Errorf("changelog file: %s does not start with expected header: %q", file, strings.TrimSpace(header))
+	}
+	rest := string(existing)[len(header):]
+	if hasChangelogRelease(rest, entry, newest.Version) {
+		warn("%s already on %s, skipping...", firstNonBlankLine(entry), file)
+		return nil
+	}
+
+	if err := ioutil.WriteFile(file, []byte(header+entry+rest), 0644); err != nil {
+		return fmt.Errorf("error writing changelog to file: %s, message: %v", file, err)
+	}
+	return nil
+}
+
+// changelogEntry split a single release changelog in header (shared with an empty changelog) and release entry.
+func changelogEntry(empty, single string) (string, string) {
+	i := 0
+	for i < len(empty) && i < len(single) && empty[i] == single[i] {
+		i++
+	}
+	footer := empty[i:]
+	if !strings.HasSuffix(single[i:], footer) {
+		return single[:i], single[i:]
+	}
+	return single[:i], strings.TrimSuffix(single[i:], footer)
+}
+
+// hasChangelogRelease check if changelog content already has the release entry title. Versions are compared ignoring
+// the rest of the title (eg.: date), and only the top title is checked for releases without version.
+func hasChangelogRelease(content, entry string, version *semver.Version) bool {
+	title := firstNonBlankLine(entry)
+	if version == nil {
+		return firstNonBlankLine(content) == title
+	}
+
+	versionRegex := regexp.MustCompile(`(^|[^0-9A-Za-z.+-])v?` + regexp.QuoteMeta(version.String()) + `($|[^0-9A-Za-z.+-])`)
+	loc := versionRegex.FindStringIndex(title)
+	if loc == nil {
+		return false
+	}
+	prefix := title[:loc[0]]
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, prefix) && versionRegex.MatchString(line[len(prefix):]) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonBlankLine(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) != "" {
+			return strings.TrimSpace(line)
+		}
+	}
+	return ""
+}
+
+func checkCommitTypes(types []string, commits []sv.GitCommitLog) error {
+	var unknown []string
+	for _, commit := range commits {
+		if !contains(commit.Message.Type, types) {
+			unknown = append(unknown, commit.Hash)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("commits without a known type found: [%s], expected types: [%s]", strings.Join(unknown, ", "), strings.Join(types, ", "))
+	}
+	return nil
+}
+
+func validateCommitMessageHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
+		validate := messageProcessor.ValidationProblems
+		if c.Bool("header-only") {
+			validate = messageProcessor.HeaderProblems
+		}
+		switch output := c.String("output"); output {
+		case "", "text":
+			if c.Bool("header-only") {
+				return validateCommitMessageHeader(c, validate)
+			}
+		case "json":
+			return validateCommitMessageJSON(c, validate)
+		default:
+			return fmt.Errorf("invalid output: %s, options: text, json", output)
+		}
+
 		branch := git.Branch()
 		detached, derr := git.IsDetached()
 
@@ -419,6 +1384,13 @@ func validateCommitMessageHandler(git sv.Git, messageProcessor sv.MessageProcess
 			return nil
 		}
 
+		if cfg.CommitMessage.Issue.IsSubjectSuffix() {
+			if err := appendOnSubject(msg, filepath); err != nil {
+				return fmt.Errorf("failed to append meta-informations on subject, error: %s", err.Error())
+			}
+			return nil
+		}
+
 		if err := appendOnFile(msg, filepath); err != nil {
 			return fmt.Errorf("failed to append meta-informations on footer, error: %s", err.Error())
 		}
@@ -427,6 +1399,239 @@ func validateCommitMessageHandler(git sv.Git, messageProcessor sv.MessageProcess
 	}
 }
 
+// validateCommitMessageHeader validate only commit message header, eg.: from editor plugins on every keystroke.
+// Branch and source checks are not applied and commit message is not enhanced.
+func validateCommitMessageHeader(c *cli.Context, validate func(message string) []sv.ValidationProblem) error {
+	commitMessage, err := readFile(filepath.Join(c.String("path"), c.String("file")))
+	if err != nil {
+		return fmt.Errorf("failed to read commit message, error: %s", err.Error())
+	}
+
+	problems := validate(commitMessage)
+	if len(problems) == 0 {
+		return nil
+	}
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.Message
+	}
+	return fmt.Errorf("invalid commit message header, error: %s", strings.Join(messages, "; "))
+}
+
+// validateCommitMessageJSON print validation problems as json array, exit with error if message is invalid.
+// Branch and source checks are not applied and commit message is not enhanced.
+func validateCommitMessageJSON(c *cli.Context, validate func(message string) []sv.ValidationProblem) error {
+	commitMessage, err := readFile(filepath.Join(c.String("path"), c.String("file")))
+	if err != nil {
+		return fmt.Errorf("failed to read commit message, error: %s", err.Error())
+	}
+
+	problems := validate(commitMessage)
+	if problems == nil {
+		problems = []sv.ValidationProblem{}
+	}
+	content, err := json.Marshal(problems)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(content))
+
+	if len(problems) > 0 {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+func hookInstallHandler(gitBinary, repoDir string) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		dir, err := getHooksPath(gitBinary, repoDir)
+		if err != nil {
+			return fmt.Errorf("error getting git hooks path, message: %v", err)
+		}
+
+		hooks := map[string]string{commitMsgHook: commitMsgHookScript}
+		if c.Bool("prepare-commit-msg") {
+			hooks[prepareCommitMsgHook] = prepareCommitMsgHookScript
+		}
+		if c.Bool("pre-push") {
+			hooks[prePushHook] = prePushHookScript
+		}
+
+		for name, script := range hooks {
+			if err := installHook(dir, name, script, c.Bool("force")); err != nil {
+				return err
+			}
+			fmt.Println(filepath.Join(dir, name))
+		}
+		return nil
+	}
+}
+
+func hookUninstallHandler(gitBinary, repoDir string) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		dir, err := getHooksPath(gitBinary, repoDir)
+		if err != nil {
+			return fmt.Errorf("error getting git hooks path, message: %v", err)
+		}
+
+		for _, name := range []string{commitMsgHook, prepareCommitMsgHook, prePushHook} {
+			removed, err := uninstallHook(dir, name)
+			if err != nil {
+				return err
+			}
+			if removed {
+				fmt.Println(filepath.Join(dir, name))
+			}
+		}
+		return nil
+	}
+}
+
+func untagHandler(git sv.Git) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if c.Args().Len() != 1 {
+			return fmt.Errorf("expected a single tag argument, got: %d", c.Args().Len())
+		}
+
+		tag := c.Args().First()
+		if _, err := sv.ToVersion(tag); err != nil && !c.Bool("force") {
+			return fmt.Errorf("tag: %s is not a valid version, use --force flag to delete it anyway", tag)
+		}
+
+		if err := git.DeleteTag(tag, c.Bool("push")); err != nil {
+			return fmt.Errorf("error deleting tag: %s, message: %v", tag, err)
+		}
+		fmt.Println(tag)
+		return nil
+	}
+}
+
+func validateMessageHandler(messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if c.Args().Len() != 1 {
+			return fmt.Errorf("expected a single commit message argument, got: %d", c.Args().Len())
+		}
+
+		if err := messageProcessor.Validate(c.Args().First()); err != nil {
+			return fmt.Errorf("invalid commit message, error: %s", err.Error())
+		}
+		fmt.Println("OK")
+		return nil
+	}
+}
+
+// zeroHash object name used by git pre-push hook for refs that don't exist.
+const zeroHash = "0000000000000000000000000000000000000000"
+
+func validatePushHandler(git sv.Git, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		remote := c.Args().First()
+		if remote == "" {
+			remote = "origin"
+		}
+
+		invalid, err := validatePushRefs(c, git, messageProcessor, remote, os.Stdin)
+		if err != nil {
+			return err
+		}
+		if invalid > 0 {
+			return fmt.Errorf("found %d invalid commit messages, push aborted", invalid)
+		}
+		return nil
+	}
+}
+
+// validatePushRefs validate commits from pre-push hook refs lines, eg.: "<local ref> <local sha> <remote ref> <remote sha>", returns the number of invalid commits.
+func validatePushRefs(c *cli.Context, git sv.Git, messageProcessor sv.MessageProcessor, remote string, input io.Reader) (int, error) {
+	invalid := 0
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 {
+			continue
+		}
+		localRef, localHash, remoteHash := fields[0], fields[1], fields[3]
+		if localHash == zeroHash {
+			continue // ref deleted on remote
+		}
+		if messageProcessor.SkipBranch(strings.TrimPrefix(localRef, "refs/heads/"), false) {
+			logVerbose(c, "ref %s skipped, branch in ignore list", localRef)
+			continue
+		}
+
+		commits, err := git.RawCommits(localHash, pushExclude(git, remote, remoteHash)...)
+		if err != nil {
+			return invalid, fmt.Errorf("error getting commits from ref: %s, message: %v", localRef, err)
+		}
+		logVerbose(c, "ref %s: validating %d commits", localRef, len(commits))
+
+		for _, commit := range commits {
+			if verr := messageProcessor.Validate(commit.Message); verr != nil {
+				invalid++
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n", commit.Hash, firstLine(commit.Message), verr)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return invalid, fmt.Errorf("error reading refs from stdin, message: %v", err)
+	}
+	return invalid, nil
+}
+
+// pushExclude revisions already on remote, new refs and remote hashes unknown locally, eg.: after a force push, use remote tracking refs instead.
+func pushExclude(git sv.Git, remote, remoteHash string) []string {
+	if remoteHash == zeroHash || !git.HasCommit(remoteHash) {
+		return []string{"--remotes=" + remote}
+	}
+	return []string{remoteHash}
+}
+
+func firstLine(message string) string {
+	return strings.SplitN(message, "\n", 2)[0]
+}
+
+func templateHandler(cfg Config) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		fmt.Print(commitTemplate(cfg))
+		return nil
+	}
+}
+
+// commitTemplate skeleton commit message, allowed types and scopes are listed as comment lines, ignored by git.
+func commitTemplate(cfg Config) string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString("# <type>(<scope>): <description>\n#\n# [optional body]\n#\n# [optional footer(s)]\n#\n")
+
+	types := commitTypes(cfg.CommitMessage.AllowedTypes(), cfg.CommitMessage.TypeDescriptions)
+	width := 0
+	for _, t := range types {
+		if len(t.Type) > width {
+			width = len(t.Type)
+		}
+	}
+	b.WriteString("# Allowed types:\n")
+	for _, t := range types {
+		if t.Description == "" {
+			fmt.Fprintf(&b, "#   %s\n", t.Type)
+			continue
+		}
+		fmt.Fprintf(&b, "#   %-*s  %s\n", width, t.Type, t.Description)
+	}
+
+	if len(cfg.CommitMessage.Scope.Values) > 0 {
+		fmt.Fprintf(&b, "#\n# Allowed scopes: %s\n", strings.Join(cfg.CommitMessage.Scope.Values, ", "))
+	} else {
+		b.WriteString("#\n# Scope is optional.\n")
+	}
+
+	fmt.Fprintf(&b, "#\n# Breaking changes: add \"!\" before \":\" or a \"%s: <description>\" footer.\n", cfg.CommitMessage.BreakingChangeFooterConfig().Key)
+	if issue := cfg.CommitMessage.IssueFooterConfig(); issue.Key != "" && !cfg.CommitMessage.Issue.IsSubjectSuffix() {
+		fmt.Fprintf(&b, "# Issue: add a \"%s%s<issue>\" footer.\n", issue.Key, issue.FooterSeparator())
+	}
+	return b.String()
+}
+
 func readFile(filepath string) (string, error) {
 	f, err := ioutil.ReadFile(filepath)
 	if err != nil {
@@ -446,6 +1651,26 @@ func appendOnFile(message, filepath string) error {
 	return err
 }
 
+func appendOnSubject(suffix, filepath string) error {
+	content, err := readFile(filepath)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitN(content, "\n", 2)
+	lines[0] = strings.TrimRight(lines[0], " ") + suffix
+	return ioutil.WriteFile(filepath, []byte(strings.Join(lines, "\n")), 0644)
+}
+
+func contains(value string, content []string) bool {
+	for _, v := range content {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
 func str(value, defaultValue string) string {
 	if value != "" {
 		return value
@@ -0,0 +1,548 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bvieira/sv4git/sv"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/urfave/cli/v2"
+)
+
+func commitOf(hash, ctype string) sv.GitCommitLog {
+	return sv.GitCommitLog{Hash: hash, Message: sv.CommitMessage{Type: ctype, Metadata: map[string]string{}}}
+}
+
+// fakeGit sv.Git with fixed data, methods not implemented here panic.
+type fakeGit struct {
+	sv.Git
+	rawCommits map[string][]sv.GitRawCommit
+	known      []string
+	excludes   [][]string
+	tags       []sv.GitTag
+	logs       []fakeLog
+	lastTag    string
+}
+
+// fakeLog commits returned by fakeGit.Log for range.
+type fakeLog struct {
+	lr      sv.LogRange
+	commits []sv.GitCommitLog
+}
+
+func (g *fakeGit) LastTag() string {
+	return g.lastTag
+}
+
+func (g *fakeGit) Tags() ([]sv.GitTag, error) {
+	return append([]sv.GitTag{}, g.tags...), nil
+}
+
+func (g *fakeGit) Log(lr sv.LogRange) ([]sv.GitCommitLog, error) {
+	for _, l := range g.logs {
+		if reflect.DeepEqual(l.lr, lr) {
+			return l.commits, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *fakeGit) RawCommits(ref string, exclude ...string) ([]sv.GitRawCommit, error) {
+	g.excludes = append(g.excludes, exclude)
+	return g.rawCommits[ref], nil
+}
+
+func (g *fakeGit) HasCommit(hash string) bool {
+	return contains(hash, g.known)
+}
+
+func testContext() *cli.Context {
+	return cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+}
+
+// captureStdout run f returning what it printed to stdout.
+func captureStdout(t *testing.T, f func() error) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	runErr := f()
+	os.Stdout = stdout
+	w.Close()
+	out, _ := ioutil.ReadAll(r)
+	r.Close()
+	return string(out), runErr
+}
+
+func Test_checkCommitTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		types   []string
+		commits []sv.GitCommitLog
+		wantErr bool
+	}{
+		{"no commits", []string{"feat"}, nil, false},
+		{"known types", []string{"feat", "fix"}, []sv.GitCommitLog{commitOf("a", "feat"), commitOf("b", "fix")}, false},
+		{"unknown type", []string{"feat"}, []sv.GitCommitLog{commitOf("a", "feat"), commitOf("b", "wip")}, true},
+		{"non conventional commit", []string{"feat"}, []sv.GitCommitLog{commitOf("a", "")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkCommitTypes(tt.types, tt.commits); (err != nil) != tt.wantErr {
+				t.Errorf("checkCommitTypes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_checkMaxVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		maxVersion string
+		wantErr    bool
+	}{
+		{"no max version", "3.0.0", "", false},
+		{"below plain version", "1.4.0", "1.5.0", false},
+		{"equal plain version", "1.5.0", "1.5.0", false},
+		{"above plain version", "1.5.1", "1.5.0", true},
+		{"inside constraint", "1.9.0", "1.x", false},
+		{"outside constraint", "2.0.0", "1.x", true},
+		{"invalid constraint", "1.0.0", "not a version", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkMaxVersion(*semver.MustParse(tt.version), tt.maxVersion); (err != nil) != tt.wantErr {
+				t.Errorf("checkMaxVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_explicitVersion(t *testing.T) {
+	current := *semver.MustParse("1.2.0")
+	tests := []struct {
+		name    string
+		value   string
+		force   bool
+		want    string
+		wantErr bool
+	}{
+		{"greater version", "1.3.0", false, "1.3.0", false},
+		{"version with v prefix", "v2.0.0", false, "2.0.0", false},
+		{"same version", "1.2.0", false, "", true},
+		{"lower version", "1.1.0", false, "", true},
+		{"lower version with force", "1.1.0", true, "1.1.0", false},
+		{"pre-release", "1.3.0-rc.1", false, "", true},
+		{"invalid version", "latest", false, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := explicitVersion(tt.value, current, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("explicitVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("explicitVersion() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_commitTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"types without descriptions", Config{CommitMessage: sv.CommitMessageConfig{Types: []string{"alpha", "beta"}}}, "\n# <type>(<scope>): <description>\n#\n# [optional body]\n#\n# [optional footer(s)]\n#\n" +
+			"# Allowed types:\n#   alpha\n#   beta\n#\n# Scope is optional.\n#\n# Breaking changes: add \"!\" before \":\" or a \"BREAKING CHANGE: <description>\" footer.\n"},
+		{"descriptions, scopes, denied types and issue footer", Config{CommitMessage: sv.CommitMessageConfig{
+			Types:            []string{"alpha", "longer", "wip"},
+			DeniedTypes:      []string{"wip"},
+			TypeDescriptions: map[string]string{"alpha": "First type"},
+			Scope:            sv.CommitMessageScopeConfig{Values: []string{"api", "ui"}},
+			Footer:           map[string]sv.CommitMessageFooterConfig{"issue": {Key: "jira"}},
+		}}, "\n# <type>(<scope>): <description>\n#\n# [optional body]\n#\n# [optional footer(s)]\n#\n" +
+			"# Allowed types:\n#   alpha   First type\n#   longer\n#\n# Allowed scopes: api, ui\n#\n# Breaking changes: add \"!\" before \":\" or a \"BREAKING CHANGE: <description>\" footer.\n# Issue: add a \"jira: <issue>\" footer.\n"},
+		{"issue on subject suffix", Config{CommitMessage: sv.CommitMessageConfig{
+			Types:  []string{"alpha"},
+			Footer: map[string]sv.CommitMessageFooterConfig{"issue": {Key: "jira"}},
+			Issue:  sv.CommitMessageIssueConfig{Placement: sv.IssuePlacementSubjectSuffix},
+		}}, "\n# <type>(<scope>): <description>\n#\n# [optional body]\n#\n# [optional footer(s)]\n#\n" +
+			"# Allowed types:\n#   alpha\n#\n# Scope is optional.\n#\n# Breaking changes: add \"!\" before \":\" or a \"BREAKING CHANGE: <description>\" footer.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commitTemplate(tt.cfg); got != tt.want {
+				t.Errorf("commitTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_filterByAuthor(t *testing.T) {
+	commit := func(hash, name, email string) sv.GitCommitLog {
+		return sv.GitCommitLog{Hash: hash, AuthorName: name, AuthorEmail: email}
+	}
+	commits := []sv.GitCommitLog{commit("a", "Jane Doe", "jane@example.com"), commit("b", "John Smith", "john@corp.com"), commit("c", "Bot", "bot@example.com")}
+
+	tests := []struct {
+		name    string
+		authors []string
+		want    []sv.GitCommitLog
+	}{
+		{"by name ignoring case", []string{"jane"}, []sv.GitCommitLog{commits[0]}},
+		{"by email", []string{"@corp.com"}, []sv.GitCommitLog{commits[1]}},
+		{"any author", []string{"JOHN", "bot@"}, []sv.GitCommitLog{commits[1], commits[2]}},
+		{"commit matching many authors listed once", []string{"jane", "example.com"}, []sv.GitCommitLog{commits[0], commits[2]}},
+		{"no match", []string{"alice"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterByAuthor(commits, tt.authors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterByAuthor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nextVersionDate(t *testing.T) {
+	commit := func(authorDate, committerDate string) sv.GitCommitLog {
+		return sv.GitCommitLog{Date: authorDate, CommitterDate: committerDate}
+	}
+	tests := []struct {
+		name       string
+		dateSource string
+		commits    []sv.GitCommitLog
+		want       string
+		wantErr    bool
+	}{
+		{"committer date of newest commit", sv.DateSourceLastCommitDate, []sv.GitCommitLog{commit("2020-01-01", "2020-05-02"), commit("2020-03-01", "2020-03-01")}, "2020-05-02", false},
+		{"newest committer date not first", sv.DateSourceLastCommitDate, []sv.GitCommitLog{commit("2020-06-01", "2020-04-01"), commit("2020-01-01", "2020-05-01")}, "2020-05-01", false},
+		{"invalid committer date", sv.DateSourceLastCommitDate, []sv.GitCommitLog{commit("2020-01-01", "invalid")}, "", true},
+		{"invalid date source", "unknown", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextVersionDate(tt.dateSource, tt.commits)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("nextVersionDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Format("2006-01-02") != tt.want {
+				t.Errorf("nextVersionDate() = %v, want %v", got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func Test_validatePushRefs(t *testing.T) {
+	const local, remote, unknown = "1111111111111111111111111111111111111111", "2222222222222222222222222222222222222222", "3333333333333333333333333333333333333333"
+	processor := sv.NewMessageProcessor(sv.CommitMessageConfig{Types: []string{"feat", "fix"}}, sv.BranchesConfig{Skip: []string{"skipped"}})
+	valid := []sv.GitRawCommit{{Hash: "a1", Message: "feat: something"}}
+	mixed := []sv.GitRawCommit{{Hash: "a1", Message: "feat: something"}, {Hash: "b2", Message: "wip"}}
+
+	tests := []struct {
+		name         string
+		input        string
+		commits      []sv.GitRawCommit
+		wantInvalid  int
+		wantExcludes [][]string
+	}{
+		{"new branch", "refs/heads/main " + local + " refs/heads/main " + zeroHash, valid, 0, [][]string{{"--remotes=origin"}}},
+		{"known remote hash", "refs/heads/main " + local + " refs/heads/main " + remote, valid, 0, [][]string{{remote}}},
+		{"force push with unknown remote hash", "refs/heads/main " + local + " refs/heads/main " + unknown, valid, 0, [][]string{{"--remotes=origin"}}},
+		{"invalid commit", "refs/heads/main " + local + " refs/heads/main " + remote, mixed, 1, [][]string{{remote}}},
+		{"deleted ref", "(delete) " + zeroHash + " refs/heads/main " + remote, mixed, 0, nil},
+		{"skipped branch", "refs/heads/skipped " + local + " refs/heads/skipped " + remote, mixed, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			git := &fakeGit{rawCommits: map[string][]sv.GitRawCommit{local: tt.commits}, known: []string{local, remote}}
+			invalid, err := validatePushRefs(testContext(), git, processor, "origin", strings.NewReader(tt.input+"\n"))
+			if err != nil {
+				t.Fatalf("validatePushRefs() error = %v", err)
+			}
+			if invalid != tt.wantInvalid {
+				t.Errorf("validatePushRefs() = %d, want %d", invalid, tt.wantInvalid)
+			}
+			if !reflect.DeepEqual(git.excludes, tt.wantExcludes) {
+				t.Errorf("validatePushRefs() excludes = %v, want %v", git.excludes, tt.wantExcludes)
+			}
+		})
+	}
+}
+
+func Test_filterByType(t *testing.T) {
+	breaking := commitOf("c", "refactor")
+	breaking.Message.IsBreakingChange = true
+	commits := []sv.GitCommitLog{commitOf("a", "feat"), commitOf("b", "fix"), breaking, commitOf("d", "")}
+
+	tests := []struct {
+		name  string
+		types []string
+		want  []sv.GitCommitLog
+	}{
+		{"single type", []string{"feat"}, []sv.GitCommitLog{commits[0]}},
+		{"many types keep order", []string{"fix", "feat"}, []sv.GitCommitLog{commits[0], commits[1]}},
+		{"breaking change from other type removed", []string{"feat", "fix"}, []sv.GitCommitLog{commits[0], commits[1]}},
+		{"breaking change type", []string{"refactor"}, []sv.GitCommitLog{breaking}},
+		{"no match", []string{"docs"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterByType(commits, tt.types); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterByType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeVersions(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2020, 5, d, 10, 0, 0, 0, time.UTC)
+	}
+	git := &fakeGit{
+		tags: []sv.GitTag{{Name: "v1.0.0", Date: day(1)}, {Name: "v1.2.0", Date: day(3)}, {Name: "v1.1.0", Date: day(3)}, {Name: "nightly", Date: day(4)}},
+		logs: []fakeLog{
+			{sv.NewLogRange(sv.TagRange, "", "v1.0.0"), []sv.GitCommitLog{commitOf("a", "feat"), commitOf("b", "fix")}},
+			{sv.NewLogRange(sv.TagRange, "v1.0.0", "v1.1.0"), []sv.GitCommitLog{commitOf("c", "feat")}},
+			{sv.NewLogRange(sv.TagRange, "v1.1.0", "v1.2.0"), []sv.GitCommitLog{commitOf("d", "feat"), commitOf("e", "fix"), commitOf("f", "fix")}},
+		},
+	}
+	want := "TAG      VERSION  DATE        COMMITS\n" +
+		"nightly  -        2020-05-04  0\n" +
+		"v1.2.0   1.2.0    2020-05-03  3\n" +
+		"v1.1.0   1.1.0    2020-05-03  1\n" +
+		"v1.0.0   1.0.0    2020-05-01  2\n"
+
+	var out bytes.Buffer
+	if err := writeVersions(&out, git, nil); err != nil {
+		t.Fatalf("writeVersions() error = %v", err)
+	}
+	if got := out.String(); got != want {
+		t.Errorf("writeVersions() = %q, want %q", got, want)
+	}
+}
+
+func Test_dailyReleaseNotes(t *testing.T) {
+	dated := func(hash, date string) sv.GitCommitLog {
+		commit := commitOf(hash, "feat")
+		commit.Date = date
+		return commit
+	}
+	git := &fakeGit{
+		logs: []fakeLog{
+			{sv.NewLogRange(sv.DateRange, "", ""), []sv.GitCommitLog{dated("a", "2020-05-03"), dated("b", "2020-05-01"), dated("c", "2020-05-03"), dated("d", "2020-05-02"), dated("e", "2020-05-03")}},
+		},
+	}
+	tests := []struct {
+		name       string
+		size       int
+		all        bool
+		wantDates  []string
+		wantLabels []string
+		wantTotal  int
+	}{
+		{"all days", 10, false, []string{"2020-05-03", "2020-05-02", "2020-05-01"}, []string{"3 commits", "1 commit", "1 commit"}, 5},
+		{"size", 2, false, []string{"2020-05-03", "2020-05-02"}, []string{"3 commits", "1 commit"}, 4},
+		{"all ignores size", 1, true, []string{"2020-05-03", "2020-05-02", "2020-05-01"}, []string{"3 commits", "1 commit", "1 commit"}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := func(commits []sv.GitCommitLog) []sv.GitCommitLog { return commits }
+			releaseNotes, total, err := dailyReleaseNotes(Config{}, git, sv.NewReleaseNoteProcessor(sv.ReleaseNotesConfig{}), identity, nil, tt.size, tt.all, false)
+			if err != nil {
+				t.Fatalf("dailyReleaseNotes() error = %v", err)
+			}
+			var dates, labels []string
+			for _, rn := range releaseNotes {
+				if rn.Version != nil || rn.Milestone != "" {
+					t.Errorf("dailyReleaseNotes() version = %v, milestone = %q, want empty", rn.Version, rn.Milestone)
+				}
+				dates = append(dates, rn.Date.Format("2006-01-02"))
+				labels = append(labels, rn.Label)
+			}
+			if !reflect.DeepEqual(dates, tt.wantDates) {
+				t.Errorf("dailyReleaseNotes() dates = %v, want %v", dates, tt.wantDates)
+			}
+			if !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("dailyReleaseNotes() labels = %v, want %v", labels, tt.wantLabels)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("dailyReleaseNotes() total = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func Test_sortTags(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2020, 5, d, 10, 0, 0, 0, time.UTC)
+	}
+	tags := func() []sv.GitTag {
+		return []sv.GitTag{{Name: "v1.0.0", Date: day(1)}, {Name: "nightly", Date: day(5)}, {Name: "v1.10.0", Date: day(2)}, {Name: "latest", Date: day(3)}, {Name: "v1.2.0", Date: day(4)}}
+	}
+	tests := []struct {
+		name    string
+		by      string
+		want    []string
+		wantErr bool
+	}{
+		{"date", tagSortDate, []string{"nightly", "v1.2.0", "latest", "v1.10.0", "v1.0.0"}, false},
+		{"semver with invalid tags last", tagSortSemver, []string{"v1.10.0", "v1.2.0", "v1.0.0", "nightly", "latest"}, false},
+		{"invalid sort", "name", []string{"v1.0.0", "nightly", "v1.10.0", "latest", "v1.2.0"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tags()
+			if err := sortTags(input, tt.by); (err != nil) != tt.wantErr {
+				t.Errorf("sortTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, tag := range input {
+				got = append(got, tag.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commitText(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  sv.CommitMessage
+		want string
+	}{
+		{"type and description", sv.CommitMessage{Type: "feat", Description: "add login"}, "feat: add login\n\n"},
+		{"scope, breaking change and body", sv.CommitMessage{Type: "fix", Scope: "auth", Description: "token", IsBreakingChange: true, Body: "details"}, "fix(auth)!: token\n\ndetails"},
+		{"pull request", sv.CommitMessage{Type: "feat", Description: "add login", PullRequest: "12"}, "feat: add login (#12)\n\n"},
+		{"non conventional", sv.CommitMessage{Description: "Merge branch 'main'"}, "Merge branch 'main'\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commitText(tt.msg); got != tt.want {
+				t.Errorf("commitText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_changelogFilter(t *testing.T) {
+	commit := func(hash, ctype, description, body string) sv.GitCommitLog {
+		return sv.GitCommitLog{Hash: hash, Message: sv.CommitMessage{Type: ctype, Description: description, Body: body}}
+	}
+	commits := []sv.GitCommitLog{commit("a", "feat", "add Auth provider", ""), commit("b", "fix", "crash on start", "caused by auth cache"), commit("c", "fix", "typo", ""), commit("d", "", "Update README", "")}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []sv.GitCommitLog
+		wantErr bool
+	}{
+		{"no filter", nil, commits, false},
+		{"type", []string{"--type", "fix"}, []sv.GitCommitLog{commits[1], commits[2]}, false},
+		{"grep header and body ignoring case", []string{"--grep", "AUTH"}, []sv.GitCommitLog{commits[0], commits[1]}, false},
+		{"grep case sensitive", []string{"--grep", "Auth", "--grep-case-sensitive"}, []sv.GitCommitLog{commits[0]}, false},
+		{"grep type prefix", []string{"--grep", "^fix:"}, []sv.GitCommitLog{commits[1], commits[2]}, false},
+		{"grep non conventional", []string{"--grep", "^update"}, []sv.GitCommitLog{commits[3]}, false},
+		{"type and grep", []string{"--type", "feat", "--grep", "crash|auth"}, []sv.GitCommitLog{commits[0]}, false},
+		{"invalid grep", []string{"--grep", "[a"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter func([]sv.GitCommitLog) []sv.GitCommitLog
+			var err error
+			app := &cli.App{
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{Name: "type"},
+					&cli.StringFlag{Name: "grep"},
+					&cli.BoolFlag{Name: "grep-case-sensitive"},
+				},
+				Action: func(c *cli.Context) error {
+					filter, err = changelogFilter(c)
+					return nil
+				},
+			}
+			if runErr := app.Run(append([]string{"git-sv"}, tt.args...)); runErr != nil {
+				t.Fatalf("app.Run() error = %v", runErr)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("changelogFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := filter(commits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changelogFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_releaseNotesHandler_nextReleaseNotes(t *testing.T) {
+	commit := func(hash, ctype, author string) sv.GitCommitLog {
+		return sv.GitCommitLog{Hash: hash, AuthorName: author, Message: sv.CommitMessage{Type: ctype, Description: "change " + hash, Metadata: map[string]string{}}}
+	}
+	git := &fakeGit{
+		lastTag: "v1.2.3",
+		logs: []fakeLog{
+			{sv.NewLogRange(sv.TagRange, "v1.2.3", ""), []sv.GitCommitLog{commit("a1b2c3d", "feat", "Jane"), commit("e4f5a6b", "fix", "John")}},
+		},
+	}
+	rncfg := sv.ReleaseNotesConfig{Headers: map[string]string{"feat": "Features", "fix": "Bug Fixes"}}
+	formatters := map[string]sv.OutputFormatter{markdownFormat: sv.NewOutputFormatter(rncfg)}
+	semverProcessor := sv.NewSemVerCommitsProcessor(sv.VersioningConfig{UpdateMinor: []string{"feat"}, UpdatePatch: []string{"fix"}}, sv.CommitMessageConfig{Types: []string{"feat", "fix"}})
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantHeader  string
+		wantItems   []string
+		missingItem string
+	}{
+		{"next version", nil, "## v1.3.0 (", []string{"change a1b2c3d", "change e4f5a6b"}, ""},
+		{"author", []string{"--author", "jane"}, "## v1.3.0 (", []string{"change a1b2c3d"}, "change e4f5a6b"},
+		{"milestone", []string{"--milestone", "Q3"}, ") - Q3", []string{"change a1b2c3d"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &cli.App{Commands: []*cli.Command{{
+				Name:   "next-release-notes",
+				Flags:  releaseNotesFlags(),
+				Action: releaseNotesHandler(Config{}, git, semverProcessor, sv.NewReleaseNoteProcessor(rncfg), formatters),
+			}}}
+			got, err := captureStdout(t, func() error {
+				return app.Run(append([]string{"git-sv", "next-release-notes"}, tt.args...))
+			})
+			if err != nil {
+				t.Fatalf("releaseNotesHandler() error = %v", err)
+			}
+			if !strings.Contains(got, tt.wantHeader) {
+				t.Errorf("releaseNotesHandler() = %q, want header containing %q", got, tt.wantHeader)
+			}
+			for _, item := range tt.wantItems {
+				if !strings.Contains(got, item) {
+					t.Errorf("releaseNotesHandler() = %q, want to contain %q", got, item)
+				}
+			}
+			if tt.missingItem != "" && strings.Contains(got, tt.missingItem) {
+				t.Errorf("releaseNotesHandler() = %q, want without %q", got, tt.missingItem)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hookMarker identifies hooks created by git-sv, only those are overwritten or removed.
+const hookMarker = "# generated by git-sv hook install"
+
+const (
+	commitMsgHook        = "commit-msg"
+	prepareCommitMsgHook = "prepare-commit-msg"
+	prePushHook          = "pre-push"
+)
+
+const commitMsgHookScript = `#!/bin/sh
+` + hookMarker + `
+
+COMMIT_MSG_FILE=$1
+COMMIT_SOURCE=""
+if git rev-parse -q --verify MERGE_HEAD >/dev/null; then
+    COMMIT_SOURCE=merge
+fi
+
+git sv vcm --path "$(pwd)" --file "$COMMIT_MSG_FILE" --source "$COMMIT_SOURCE"
+`
+
+const prepareCommitMsgHookScript = `#!/bin/sh
+` + hookMarker + `
+
+COMMIT_MSG_FILE=$1
+COMMIT_SOURCE=$2
+SHA1=$3
+
+git sv vcm --path "$(pwd)" --file "$COMMIT_MSG_FILE" --source "$COMMIT_SOURCE"
+`
+
+const prePushHookScript = `#!/bin/sh
+` + hookMarker + `
+
+git sv validate-push "$1"
+`
+
+// getHooksPath get git hooks dir, respecting core.hooksPath.
+func getHooksPath(gitBinary, dir string) (string, error) {
+	return getGitPath(gitBinary, dir, "hooks")
+}
+
+func isGeneratedHook(path string) (bool, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return strings.Contains(string(content), hookMarker), nil
+}
+
+func installHook(dir, name, script string, force bool) error {
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil && !force {
+		generated, gerr := isGeneratedHook(path)
+		if gerr != nil {
+			return fmt.Errorf("could not read hook: %s, error: %v", path, gerr)
+		}
+		if !generated {
+			return fmt.Errorf("hook: %s already exists, use --force flag to overwrite it", path)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("could not create hooks dir: %s, error: %v", dir, err)
+	}
+	if err := ioutil.WriteFile(path, []byte(script), 0755); err != nil {
+		return fmt.Errorf("could not write hook: %s, error: %v", path, err)
+	}
+	return os.Chmod(path, 0755)
+}
+
+func uninstallHook(dir, name string) (bool, error) {
+	path := filepath.Join(dir, name)
+	generated, err := isGeneratedHook(path)
+	if err != nil {
+		return false, fmt.Errorf("could not read hook: %s, error: %v", path, err)
+	}
+	if !generated {
+		return false, nil
+	}
+	if err := os.Remove(path); err != nil {
+		return false, fmt.Errorf("could not remove hook: %s, error: %v", path, err)
+	}
+	return true, nil
+}
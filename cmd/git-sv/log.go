@@ -1,7 +1,56 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+)
 
 func warn(format string, values ...interface{}) {
 	fmt.Printf("WARN: "+format+"\n", values...)
 }
+
+// warnStderr warn on stderr, keeping command output clean.
+func warnStderr(format string, values ...interface{}) {
+	fmt.Fprintf(os.Stderr, "WARN: "+format+"\n", values...)
+}
+
+// warnedTags tags already reported by warnSkippedTag.
+var warnedTags = make(map[string]bool)
+
+// warnSkippedTag warn on stderr, once per tag, about a tag skipped because it isn't a valid version.
+func warnSkippedTag(tag string) {
+	if !warnedTags[tag] {
+		warnedTags[tag] = true
+		warnStderr("skipping tag: %s, it's not a valid version, use --strict-tags flag to fail instead", tag)
+	}
+}
+
+// logVerbose write debug info to stderr when global verbose flag is enabled.
+func logVerbose(c *cli.Context, format string, values ...interface{}) {
+	if c.Bool("verbose") {
+		fmt.Fprintf(os.Stderr, "[verbose] "+format+"\n", values...)
+	}
+}
+
+// isTerminal check if file is a terminal, eg.: to skip progress output when stderr is redirected.
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// logProgress overwrite current stderr line with progress info, only when stderr is a terminal.
+func logProgress(format string, values ...interface{}) {
+	if isTerminal(os.Stderr) {
+		fmt.Fprintf(os.Stderr, "\r"+format+"\033[K", values...)
+	}
+}
+
+// logSummary write a one-line summary to stderr, replacing progress line if any.
+func logSummary(format string, values ...interface{}) {
+	if isTerminal(os.Stderr) {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	fmt.Fprintf(os.Stderr, format+"\n", values...)
+}
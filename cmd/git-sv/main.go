@@ -13,32 +13,73 @@ import (
 // Version for git-sv
 var Version = ""
 
+// config file names, without extension, check configExtensions for supported extensions.
 const (
-	configFilename     = "config.yml"
-	repoConfigFilename = ".sv4git.yml"
+	configFilename     = "config"
+	repoConfigFilename = ".sv4git"
+)
+
+// exit code used by next-version when there is no version update and fail-if-unchanged is used.
+const noVersionUpdateExitCode = 3
+
+const (
+	markdownFormat = "markdown"
+	htmlFormat     = "html"
+	adocFormat     = "adoc"
+	jsonFormat     = "json"
+	slackFormat    = "slack"
+	atomFormat     = "atom"
+	tsvFormat      = "tsv"
+)
+
+// changelog tag sort options.
+const (
+	tagSortDate   = "date"
+	tagSortSemver = "semver"
+)
+
+// changelog group-by options.
+const (
+	groupByTag = "tag"
+	groupByDay = "day"
 )
 
 func main() {
 	log.SetFlags(0)
 
 	envCfg := loadEnvConfig()
+	gitBinary := gitBinaryFromArgs(os.Args[1:], envCfg.GitBinary)
+	repoDir := repoDirFromArgs(os.Args[1:], "")
 
 	cfg := defaultConfig()
+	cfgFormat := yamlConfigFormat
 
 	if envCfg.Home != "" {
-		if homeCfg, err := loadConfig(filepath.Join(envCfg.Home, configFilename)); err == nil {
+		homeCfgPath := findConfig(envCfg.Home, configFilename)
+		if homeCfg, err := loadConfig(homeCfgPath); err == nil {
+			cfgFormat = configFormat(homeCfgPath)
+			homeCfg, eerr := extendConfig(homeCfg, envCfg.Home)
+			if eerr != nil {
+				log.Fatal(eerr)
+			}
 			if merr := merge(&cfg, homeCfg); merr != nil {
 				log.Fatal(merr)
 			}
 		}
 	}
 
-	repoPath, rerr := getRepoPath()
+	repoPath, rerr := getRepoPath(gitBinary, repoDir)
 	if rerr != nil {
 		log.Fatal(rerr)
 	}
 
-	if repoCfg, err := loadConfig(filepath.Join(repoPath, repoConfigFilename)); err == nil {
+	repoCfgPath := findConfig(repoPath, repoConfigFilename)
+	if repoCfg, err := loadConfig(repoCfgPath); err == nil {
+		cfgFormat = configFormat(repoCfgPath)
+		repoCfg, eerr := extendConfig(repoCfg, repoPath)
+		if eerr != nil {
+			log.Fatal(eerr)
+		}
 		if merr := merge(&cfg, repoCfg); merr != nil {
 			log.Fatal(merr)
 		}
@@ -47,16 +88,84 @@ func main() {
 		}
 	}
 
+	applyEnvConfig(&cfg, envCfg)
+	if err := validateConfig(cfg); err != nil {
+		log.Fatal(err)
+	}
+
 	messageProcessor := sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches)
-	git := sv.NewGit(messageProcessor, cfg.Tag)
+	git := sv.NewGit(messageProcessor, cfg.Tag, gitBinary)
+	git.SetDir(repoDir)
+	git.SetSkippedTagHandler(warnSkippedTag)
+	if cfg.Cache.Enabled {
+		cacheDir, cerr := getCacheDir(gitBinary, repoDir, cfg.Cache)
+		if cerr != nil {
+			log.Fatal(cerr)
+		}
+		git.SetLogCache(cacheDir)
+	}
+	var recentTypesFile string
+	if cfg.CommitMessage.Prompt.RecentFirst {
+		cacheDir, cerr := getCacheDir(gitBinary, repoDir, cfg.Cache)
+		if cerr != nil {
+			log.Fatal(cerr)
+		}
+		recentTypesFile = filepath.Join(cacheDir, "recent-types")
+	}
+	// withScopes run action with scopes from commit-message.scope.values-from, loaded only by commands using scopes,
+	// if required is false, a warning is shown and configured values are used when scopes can't be loaded.
+	withScopes := func(required bool, action func(cfg Config, messageProcessor sv.MessageProcessor) cli.ActionFunc) cli.ActionFunc {
+		return func(c *cli.Context) error {
+			if cfg.CommitMessage.Scope.ValuesFrom == "" {
+				return action(cfg, messageProcessor)(c)
+			}
+			scopedCfg, err := withScopeValues(cfg, gitBinary, repoDir, repoPath)
+			if err != nil {
+				if required {
+					return err
+				}
+				warnStderr("%v", err)
+				return action(cfg, messageProcessor)(c)
+			}
+			return action(scopedCfg, sv.NewMessageProcessor(scopedCfg.CommitMessage, scopedCfg.Branches))(c)
+		}
+	}
+
 	semverProcessor := sv.NewSemVerCommitsProcessor(cfg.Versioning, cfg.CommitMessage)
 	releasenotesProcessor := sv.NewReleaseNoteProcessor(cfg.ReleaseNotes)
-	outputFormatter := sv.NewOutputFormatter()
+	outputFormatters := map[string]sv.OutputFormatter{
+		markdownFormat: sv.NewOutputFormatter(cfg.ReleaseNotes),
+		htmlFormat:     sv.NewHTMLOutputFormatter(cfg.ReleaseNotes),
+		adocFormat:     sv.NewAsciiDocOutputFormatter(cfg.ReleaseNotes),
+		jsonFormat:     sv.NewJSONOutputFormatter(),
+		slackFormat:    sv.NewSlackOutputFormatter(cfg.ReleaseNotes),
+		atomFormat:     sv.NewAtomOutputFormatter(cfg.ReleaseNotes),
+		tsvFormat:      sv.NewTSVOutputFormatter(true),
+	}
+	if cfg.ReleaseNotes.Template != "" {
+		formatter, ferr := loadTemplateOutputFormatter(repoPath, cfg.ReleaseNotes)
+		if ferr != nil {
+			log.Fatal(ferr)
+		}
+		outputFormatters[markdownFormat] = formatter
+	}
 
 	app := cli.NewApp()
 	app.Name = "sv"
 	app.Version = Version
 	app.Usage = "semantic version for git"
+	cli.VersionFlag = &cli.BoolFlag{Name: "version", Usage: "print the version"} // -v is used by verbose flag
+	app.Flags = []cli.Flag{
+		&cli.BoolFlag{Name: "verbose", Aliases: []string{"v"}, Usage: "log last tag, git log range, parsed commits and version bump to stderr"},
+		&cli.BoolFlag{Name: "fetch", Usage: "fetch tags from remote before running command"},
+		&cli.StringFlag{Name: "remote", Value: "origin", Usage: "remote used to fetch tags"},
+		&cli.BoolFlag{Name: "merged-only", Usage: "only consider tags reachable from current branch (HEAD), eg.: on hotfix branches, overrides tag.merged-only config"},
+		&cli.BoolFlag{Name: "strict-tags", Usage: "fail if last tag isn't a valid version, eg.: latest, instead of skipping it and using the previous valid tag"},
+		&cli.BoolFlag{Name: "no-cache", Usage: "don't use git log cache, even if cache.enabled is defined on config"},
+		&cli.StringFlag{Name: "git-binary", Value: gitBinary, Usage: "git executable used on every git command, can also be defined with SV4GIT_GIT_BINARY env var"},
+		&cli.StringFlag{Name: "repo-path", Usage: "run git commands and load repository config from the given dir instead of current dir"},
+	}
+	app.Before = beforeHandler(git)
 	app.Commands = []*cli.Command{
 		{
 			Name:    "config",
@@ -66,12 +175,34 @@ func main() {
 				{
 					Name:   "default",
 					Usage:  "show default config",
-					Action: configDefaultHandler(),
+					Action: configDefaultHandler(cfgFormat),
+					Flags: []cli.Flag{
+						&cli.StringFlag{Name: "format", Value: cfgFormat, Usage: "output format, use: yaml, toml or json"},
+					},
 				},
 				{
 					Name:   "show",
 					Usage:  "show current config",
-					Action: configShowHandler(cfg),
+					Action: configShowHandler(cfg, cfgFormat),
+					Flags: []cli.Flag{
+						&cli.StringFlag{Name: "format", Value: cfgFormat, Usage: "output format, use: yaml, toml or json"},
+					},
+				},
+				{
+					Name:   "diff",
+					Usage:  "show current config keys that differ from default config",
+					Action: configDiffHandler(cfg),
+					Flags: []cli.Flag{
+						&cli.StringFlag{Name: "against", Usage: "compare with the given config file (merged on default config) instead of default config"},
+					},
+				},
+				{
+					Name:   "types",
+					Usage:  "show configured commit types and scopes",
+					Action: withScopes(false, func(cfg Config, _ sv.MessageProcessor) cli.ActionFunc { return configTypesHandler(cfg) }),
+					Flags: []cli.Flag{
+						&cli.BoolFlag{Name: "json", Usage: "output types, descriptions and scopes as json"},
+					},
 				},
 			},
 		},
@@ -86,6 +217,48 @@ func main() {
 			Aliases: []string{"nv"},
 			Usage:   "generate the next version based on git commit messages",
 			Action:  nextVersionHandler(git, semverProcessor),
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "metadata", Usage: "build metadata appended to version, eg.: build.42"},
+				&cli.BoolFlag{Name: "fail-if-unchanged", Usage: "exit with code 3 if there is no version update"},
+				&cli.StringFlag{Name: "max-version", Usage: "fail if next version exceeds this version or constraint, eg.: 1.x, 1.5.0"},
+				&cli.StringSliceFlag{Name: "path", Usage: "only consider commits touching the given path, can be used multiple times"},
+				&cli.StringFlag{Name: "branch", Usage: "compute next version from the given branch history"},
+				&cli.BoolFlag{Name: "release", Usage: "promote current pre-release version to final, eg.: 1.2.0-rc.3 to 1.2.0"},
+				&cli.StringFlag{Name: "module", Usage: "compute version of a sub directory module, using only tags prefixed and commits under it, eg.: sub for sub/v1.2.3 tags"},
+				&cli.StringFlag{Name: "write-to", Usage: "also write next version to the given file, eg.: version.txt"},
+			},
+		},
+		{
+			Name:    "next-tag",
+			Aliases: []string{"nt"},
+			Usage:   "generate the next tag name, using tag pattern, based on git commit messages",
+			Action:  nextTagHandler(git, semverProcessor),
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "metadata", Usage: "build metadata appended to tag, eg.: build.42"},
+				&cli.BoolFlag{Name: "fail-if-unchanged", Usage: "exit with code 3 if there is no version update"},
+				&cli.StringFlag{Name: "max-version", Usage: "fail if next version exceeds this version or constraint, eg.: 1.x, 1.5.0"},
+				&cli.StringSliceFlag{Name: "path", Usage: "only consider commits touching the given path, can be used multiple times"},
+				&cli.StringFlag{Name: "branch", Usage: "compute next version from the given branch history"},
+				&cli.BoolFlag{Name: "release", Usage: "promote current pre-release version to final, eg.: 1.2.0-rc.3 to 1.2.0"},
+				&cli.StringFlag{Name: "module", Usage: "compute version of a sub directory module, using only tags prefixed and commits under it, eg.: sub for sub/v1.2.3 tags"},
+				&cli.StringFlag{Name: "write-to", Usage: "also write next tag to the given file, eg.: tag.txt"},
+			},
+		},
+		{
+			Name:   "versions",
+			Usage:  "list every tag with its parsed version, date and commit count since previous tag",
+			Action: versionsHandler(git),
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{Name: "path", Usage: "only count commits touching the given path, can be used multiple times"},
+			},
+		},
+		{
+			Name:   "explain",
+			Usage:  "list commits that cause next version update, grouped by major, minor and patch",
+			Action: explainHandler(git, semverProcessor),
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{Name: "path", Usage: "only consider commits touching the given path, can be used multiple times"},
+			},
 		},
 		{
 			Name:        "commit-log",
@@ -98,6 +271,9 @@ func main() {
 				&cli.StringFlag{Name: "r", Aliases: []string{"range"}, Usage: "type of range of commits, use: tag, date or hash", Value: string(sv.TagRange)},
 				&cli.StringFlag{Name: "s", Aliases: []string{"start"}, Usage: "start range of git log revision range, if date, the value is used on since flag instead"},
 				&cli.StringFlag{Name: "e", Aliases: []string{"end"}, Usage: "end range of git log revision range, if date, the value is used on until flag instead"},
+				&cli.StringSliceFlag{Name: "path", Usage: "only consider commits touching the given path, can be used multiple times"},
+				&cli.IntFlag{Name: "limit", Aliases: []string{"n"}, Usage: "max number of commits to list, newest first"},
+				&cli.StringFlag{Name: "date-type", Value: "author", Usage: "date used on date field, use: author or committer, both are available as date and committerDate"},
 			},
 		},
 		{
@@ -105,29 +281,57 @@ func main() {
 			Aliases:     []string{"cn"},
 			Usage:       "generate a commit notes according to range",
 			Description: "The range filter is used based on git log filters, check https://git-scm.com/docs/git-log for more info. When flag range is \"tag\" and start is empty, last tag created will be used instead. When flag range is \"date\", if \"end\" is YYYY-MM-DD the range will be inclusive.",
-			Action:      commitNotesHandler(git, releasenotesProcessor, outputFormatter),
+			Action:      commitNotesHandler(git, releasenotesProcessor, outputFormatters),
 			Flags: []cli.Flag{
 				&cli.StringFlag{Name: "r", Aliases: []string{"range"}, Usage: "type of range of commits, use: tag, date or hash", Required: true},
 				&cli.StringFlag{Name: "s", Aliases: []string{"start"}, Usage: "start range of git log revision range, if date, the value is used on since flag instead"},
 				&cli.StringFlag{Name: "e", Aliases: []string{"end"}, Usage: "end range of git log revision range, if date, the value is used on until flag instead"},
+				&cli.StringSliceFlag{Name: "path", Usage: "only consider commits touching the given path, can be used multiple times"},
+				&cli.StringFlag{Name: "format", Value: markdownFormat, Usage: "output format, use: markdown, html, adoc, json, slack, atom or tsv"},
 			},
 		},
 		{
 			Name:    "release-notes",
 			Aliases: []string{"rn"},
 			Usage:   "generate release notes",
-			Action:  releaseNotesHandler(git, semverProcessor, releasenotesProcessor, outputFormatter),
-			Flags:   []cli.Flag{&cli.StringFlag{Name: "t", Aliases: []string{"tag"}, Usage: "get release note from tag"}},
+			Action:  releaseNotesHandler(cfg, git, semverProcessor, releasenotesProcessor, outputFormatters),
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{Name: "t", Aliases: []string{"tag"}, Usage: "get release note from tag"},
+			}, releaseNotesFlags()...),
+		},
+		{
+			Name:    "next-release-notes",
+			Aliases: []string{"nrn"},
+			Usage:   "generate release notes for the next version using commits since last tag",
+			Action:  releaseNotesHandler(cfg, git, semverProcessor, releasenotesProcessor, outputFormatters),
+			Flags:   releaseNotesFlags(),
 		},
 		{
 			Name:    "changelog",
 			Aliases: []string{"cgl"},
 			Usage:   "generate changelog",
-			Action:  changelogHandler(git, semverProcessor, releasenotesProcessor, outputFormatter),
+			Action:  changelogHandler(cfg, git, semverProcessor, releasenotesProcessor, outputFormatters),
 			Flags: []cli.Flag{
 				&cli.IntFlag{Name: "size", Value: 10, Aliases: []string{"n"}, Usage: "get changelog from last 'n' tags"},
 				&cli.BoolFlag{Name: "all", Usage: "ignore size parameter, get changelog for every tag"},
 				&cli.BoolFlag{Name: "add-next-version", Usage: "add next version on change log (commits since last tag, but only if there is a new version to release)"},
+				&cli.BoolFlag{Name: "add-unreleased", Usage: "add unreleased section on change log (commits since last tag, but only if there are commits)"},
+				&cli.StringFlag{Name: "date-source", Usage: "date used on unreleased version release notes: now or last-commit-date, overrides release-notes.date-source config"},
+				&cli.StringFlag{Name: "start", Usage: "generate a single section from a commit hash range starting at the given hash, instead of using tags"},
+				&cli.StringFlag{Name: "end", Usage: "end of commit hash range, if empty, HEAD is used"},
+				&cli.StringFlag{Name: "split-output", Usage: "write each version release notes to its own file on the given dir, eg.: changelog/1.2.0.md"},
+				&cli.BoolFlag{Name: "force", Usage: "overwrite existing files when using split-output"},
+				&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "write changelog to the given file instead of stdout, eg.: CHANGELOG.md"},
+				&cli.BoolFlag{Name: "prepend", Usage: "only insert newest release (or next version/unreleased if requested) at the top of output file, skipped if it's already there"},
+				&cli.BoolFlag{Name: "no-header", Usage: "omit header row when using tsv format"},
+				&cli.StringFlag{Name: "sort", Value: tagSortDate, Usage: "sort tags by date or semver, with semver, tags that aren't valid versions are moved to the end"},
+				&cli.StringSliceFlag{Name: "type", Usage: "only list commits of the given type, eg.: fix, can be used multiple times"},
+				&cli.StringFlag{Name: "grep", Usage: "only list commits whose subject or body match the given regex, case-insensitive, eg.: auth"},
+				&cli.BoolFlag{Name: "grep-case-sensitive", Usage: "match grep regex case-sensitive"},
+				&cli.StringFlag{Name: "group-by", Value: groupByTag, Usage: "group changelog entries by tag or day, with day, size is the number of days and each day title shows its commit count"},
+				&cli.StringSliceFlag{Name: "path", Usage: "only consider commits touching the given path, can be used multiple times"},
+				&cli.StringFlag{Name: "format", Value: markdownFormat, Usage: "output format, use: markdown, html, adoc, json, slack, atom or tsv"},
+				&cli.BoolFlag{Name: "strict", Usage: "fail if a commit does not match any configured commit type"},
 			},
 		},
 		{
@@ -135,24 +339,98 @@ func main() {
 			Aliases: []string{"tg"},
 			Usage:   "generate tag with version based on git commit messages",
 			Action:  tagHandler(git, semverProcessor),
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "metadata", Usage: "build metadata appended to version and tag, eg.: build.42"},
+				&cli.StringFlag{Name: "max-version", Usage: "fail if next version exceeds this version or constraint, eg.: 1.x, 1.5.0"},
+				&cli.StringSliceFlag{Name: "path", Usage: "only consider commits touching the given path, can be used multiple times"},
+				&cli.BoolFlag{Name: "allow-dirty", Usage: "allow tagging when working tree has uncommitted changes"},
+				&cli.StringFlag{Name: "branch", Usage: "compute next version from the given branch history and tag its last commit"},
+				&cli.BoolFlag{Name: "release", Usage: "promote current pre-release version to final, eg.: 1.2.0-rc.3 to 1.2.0"},
+				&cli.StringFlag{Name: "version", Usage: "tag with the given version instead of computing it, eg.: 2.5.0"},
+				&cli.BoolFlag{Name: "force", Usage: "allow tagging a version lower or equal than current version when using version flag"},
+				&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "create tag without confirmation, confirmation is also skipped when not running on a terminal"},
+				&cli.StringFlag{Name: "write-to", Usage: "also write tagged version to the given file, eg.: version.txt"},
+			},
 		},
 		{
 			Name:    "commit",
 			Aliases: []string{"cmt"},
 			Usage:   "execute git commit with convetional commit message helper",
-			Action:  commitHandler(cfg, git, messageProcessor),
+			Action: withScopes(true, func(cfg Config, messageProcessor sv.MessageProcessor) cli.ActionFunc {
+				return commitHandler(cfg, git, messageProcessor, recentTypesFile)
+			}),
+			Flags: []cli.Flag{
+				&cli.BoolFlag{Name: "sign", Aliases: []string{"S"}, Usage: "gpg sign commit, if not defined, git config commit.gpgsign is used, use --sign=false to disable it"},
+			},
 		},
 		{
 			Name:    "validate-commit-message",
 			Aliases: []string{"vcm"},
 			Usage:   "use as prepare-commit-message hook to validate and enhance commit message",
-			Action:  validateCommitMessageHandler(git, messageProcessor),
+			Action: withScopes(true, func(cfg Config, messageProcessor sv.MessageProcessor) cli.ActionFunc {
+				return validateCommitMessageHandler(cfg, git, messageProcessor)
+			}),
 			Flags: []cli.Flag{
 				&cli.StringFlag{Name: "path", Required: true, Usage: "git working directory"},
 				&cli.StringFlag{Name: "file", Required: true, Usage: "name of the file that contains the commit log message"},
 				&cli.StringFlag{Name: "source", Required: true, Usage: "source of the commit message"},
+				&cli.StringFlag{Name: "output", Value: "text", Usage: "output format: text or json, json prints validation problems with line and column, without enhancing commit message"},
+				&cli.BoolFlag{Name: "header-only", Usage: "only validate header rules, without branch checks, body and footer rules or enhancing commit message, eg.: for editor plugins"},
+			},
+		},
+		{
+			Name:      "validate-push",
+			Usage:     "use as pre-push hook to validate commit messages not pushed yet, reads refs from stdin",
+			ArgsUsage: "[remote]",
+			Action: withScopes(true, func(_ Config, messageProcessor sv.MessageProcessor) cli.ActionFunc {
+				return validatePushHandler(git, messageProcessor)
+			}),
+		},
+		{
+			Name:  "hook",
+			Usage: "manage git hooks used to validate commit messages",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "install",
+					Usage:  "install commit-msg hook, respecting core.hooksPath",
+					Action: hookInstallHandler(gitBinary, repoDir),
+					Flags: []cli.Flag{
+						&cli.BoolFlag{Name: "prepare-commit-msg", Usage: "also install prepare-commit-msg hook"},
+						&cli.BoolFlag{Name: "pre-push", Usage: "also install pre-push hook calling validate-push"},
+						&cli.BoolFlag{Name: "force", Usage: "overwrite existing hooks not created by git-sv"},
+					},
+				},
+				{
+					Name:   "uninstall",
+					Usage:  "remove hooks created by install",
+					Action: hookUninstallHandler(gitBinary, repoDir),
+				},
+			},
+		},
+		{
+			Name:      "untag",
+			Usage:     "delete a tag created for an aborted release",
+			ArgsUsage: "<tag>",
+			Action:    untagHandler(git),
+			Flags: []cli.Flag{
+				&cli.BoolFlag{Name: "push", Usage: "also delete tag from remote"},
+				&cli.BoolFlag{Name: "force", Usage: "delete tag even if it is not a valid version"},
 			},
 		},
+		{
+			Name:   "template",
+			Usage:  "print a commit message template with allowed types and scopes, can be used as git commit.template",
+			Action: withScopes(false, func(cfg Config, _ sv.MessageProcessor) cli.ActionFunc { return templateHandler(cfg) }),
+		},
+		{
+			Name:      "validate-message",
+			Aliases:   []string{"vm"},
+			Usage:     "validate a commit message passed as argument",
+			ArgsUsage: "<message>",
+			Action: withScopes(true, func(_ Config, messageProcessor sv.MessageProcessor) cli.ActionFunc {
+				return validateMessageHandler(messageProcessor)
+			}),
+		},
 	}
 
 	apperr := app.Run(os.Args)
@@ -160,3 +438,17 @@ func main() {
 		log.Fatal(apperr)
 	}
 }
+
+// releaseNotesFlags flags shared by release-notes and next-release-notes commands.
+func releaseNotesFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{Name: "path", Usage: "only consider commits touching the given path, can be used multiple times"},
+		&cli.StringFlag{Name: "format", Value: markdownFormat, Usage: "output format, use: markdown, html, adoc, json, slack, atom or tsv"},
+		&cli.BoolFlag{Name: "strict", Usage: "fail if a commit does not match any configured commit type"},
+		&cli.BoolFlag{Name: "breaking-only", Usage: "render only breaking changes section"},
+		&cli.StringSliceFlag{Name: "type", Usage: "only list commits of the given type, eg.: fix, can be used multiple times, with breaking-only, only breaking changes from these types are listed"},
+		&cli.StringSliceFlag{Name: "author", Usage: "only list commits whose author name or email contains the value, ignoring case, can be used multiple times"},
+		&cli.StringFlag{Name: "date-source", Usage: "date used on unreleased version release notes: now or last-commit-date, overrides release-notes.date-source config"},
+		&cli.StringFlag{Name: "milestone", Usage: "milestone or planned date label added to release notes title, eg.: \"Q3 Launch\""},
+	}
+}
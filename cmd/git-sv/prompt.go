@@ -2,19 +2,26 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"strings"
 
+	"github.com/bvieira/sv4git/sv"
 	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
 )
 
 type commitType struct {
-	Type        string
-	Description string
-	Example     string
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Example     string `json:"example,omitempty"`
 }
 
-func promptType(types []string) (commitType, error) {
+// commitTypes list configured types with built-in descriptions, overridden by configured descriptions.
+func commitTypes(types []string, descriptions map[string]string) []commitType {
 	defaultTypes := map[string]commitType{
 		"build":    {Type: "build", Description: "changes that affect the build system or external dependencies", Example: "gradle, maven, go mod, npm"},
 		"ci":       {Type: "ci", Description: "changes to our CI configuration files and scripts", Example: "Circle, BrowserStack, SauceLabs"},
@@ -31,17 +38,64 @@ func promptType(types []string) (commitType, error) {
 
 	var items []commitType
 	for _, t := range types {
-		if v, exists := defaultTypes[t]; exists {
-			items = append(items, v)
-		} else {
-			items = append(items, commitType{Type: t})
+		item, exists := defaultTypes[t]
+		if !exists {
+			item = commitType{Type: t}
 		}
+		if description, exists := descriptions[t]; exists {
+			item.Description = description
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// orderTypes list types present on order first, following order, then remaining types keeping their original order.
+func orderTypes(types, order []string) []string {
+	var result []string
+	for _, t := range order {
+		if contains(t, types) && !contains(t, result) {
+			result = append(result, t)
+		}
+	}
+	for _, t := range types {
+		if !contains(t, result) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// loadRecentTypes read types used on commit command, most recent first, if file can't be read, returns empty.
+func loadRecentTypes(file string) []string {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil
 	}
+	return strings.Fields(string(content))
+}
+
+// saveRecentType move type to the top of recent types file.
+func saveRecentType(file, ctype string) error {
+	types := []string{ctype}
+	for _, t := range loadRecentTypes(file) {
+		if t != ctype {
+			types = append(types, t)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, []byte(strings.Join(types, "\n")+"\n"), 0644)
+}
+
+func promptType(types []string, descriptions map[string]string) (commitType, error) {
+	items := commitTypes(types, descriptions)
 
 	template := &promptui.SelectTemplates{
 		Label:    "{{ . }}",
-		Active:   "> {{ .Type | white }} - {{ .Description | faint }}",
-		Inactive: "  {{ .Type | white }} - {{ .Description | faint }}",
+		Active:   "> {{ .Type | white }}{{ if .Description }} - {{ .Description | faint }}{{ end }}",
+		Inactive: "  {{ .Type | white }}{{ if .Description }} - {{ .Description | faint }}{{ end }}",
 		Selected: `{{ "type:" | faint }} {{ .Type | white }}`,
 		Details: `
 {{ "Type:" | faint }}	{{ .Type }}
@@ -56,7 +110,19 @@ func promptType(types []string) (commitType, error) {
 	return items[i], nil
 }
 
-func promptScope(values []string) (string, error) {
+func promptScope(values []string, required bool) (string, error) {
+	if required && len(values) > 0 {
+		var nonEmpty []string
+		for _, v := range values {
+			if v != "" {
+				nonEmpty = append(nonEmpty, v)
+			}
+		}
+		values = nonEmpty
+	}
+	if sv.HasScopePattern(values) {
+		return promptText(fmt.Sprintf("scope (%s)", strings.Join(values, ", ")), sv.ScopeRegex(values), "")
+	}
 	if len(values) > 0 {
 		selected, err := promptSelect("scope", values, nil)
 		if err != nil {
@@ -64,6 +130,9 @@ func promptScope(values []string) (string, error) {
 		}
 		return values[selected], nil
 	}
+	if required {
+		return promptText("scope", "^[a-z0-9-]+$", "")
+	}
 	return promptText("scope", "^[a-z0-9-]*$", "")
 }
 
@@ -124,3 +193,8 @@ func promptConfirm(label string) (bool, error) {
 	}
 	return r == "y", nil
 }
+
+// isInteractive check if stdin is a terminal, prompts should be skipped otherwise, eg.: on CI or piped input.
+func isInteractive() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_orderTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []string
+		order []string
+		want  []string
+	}{
+		{"without recent types", []string{"feat", "fix", "docs"}, nil, []string{"feat", "fix", "docs"}},
+		{"recent types first", []string{"feat", "fix", "docs", "chore"}, []string{"docs", "fix"}, []string{"docs", "fix", "feat", "chore"}},
+		{"ignore unknown recent types", []string{"feat", "fix"}, []string{"wip", "fix"}, []string{"fix", "feat"}},
+		{"ignore duplicated recent types", []string{"feat", "fix"}, []string{"fix", "fix", "feat"}, []string{"fix", "feat"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderTypes(tt.types, tt.order); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("orderTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_saveRecentType(t *testing.T) {
+	tests := []struct {
+		name  string
+		saved []string
+		want  []string
+	}{
+		{"first type", []string{"feat"}, []string{"feat"}},
+		{"most recent first", []string{"feat", "fix", "docs"}, []string{"docs", "fix", "feat"}},
+		{"move existing type to the top", []string{"feat", "fix", "feat"}, []string{"feat", "fix"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "sv4git", "recent-types")
+			for _, ctype := range tt.saved {
+				if err := saveRecentType(file, ctype); err != nil {
+					t.Fatalf("saveRecentType() error = %v", err)
+				}
+			}
+			if got := loadRecentTypes(file); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadRecentTypes() = %v, want %v", got, tt.want)
+			}
+			if got := orderTypes([]string{"chore", "docs", "feat", "fix"}, loadRecentTypes(file)); !reflect.DeepEqual(got[:len(tt.want)], tt.want) {
+				t.Errorf("orderTypes() = %v, want %v first", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadRecentTypes_missingFile(t *testing.T) {
+	if got := loadRecentTypes(filepath.Join(t.TempDir(), "missing")); got != nil {
+		t.Errorf("loadRecentTypes() = %v, want nil", got)
+	}
+}
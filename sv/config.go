@@ -4,10 +4,35 @@ package sv
 
 // CommitMessageConfig config a commit message.
 type CommitMessageConfig struct {
-	Types  []string                             `yaml:"types"`
-	Scope  CommitMessageScopeConfig             `yaml:"scope"`
-	Footer map[string]CommitMessageFooterConfig `yaml:"footer"`
-	Issue  CommitMessageIssueConfig             `yaml:"issue"`
+	Types            []string                             `yaml:"types"`
+	DeniedTypes      []string                             `yaml:"denied-types"`
+	TypeDescriptions map[string]string                    `yaml:"type-descriptions"`
+	Scope            CommitMessageScopeConfig             `yaml:"scope"`
+	Subject          CommitMessageSubjectConfig           `yaml:"subject"`
+	Body             CommitMessageBodyConfig              `yaml:"body"`
+	Footer           map[string]CommitMessageFooterConfig `yaml:"footer"`
+	FooterValidation CommitMessageFooterValidationConfig  `yaml:"footer-validation"`
+	Issue            CommitMessageIssueConfig             `yaml:"issue"`
+	AllowFixup       bool                                 `yaml:"allow-fixup"`
+	HeaderHint       string                               `yaml:"header-hint"`
+	Prompt           CommitMessagePromptConfig            `yaml:"prompt"`
+}
+
+// CommitMessagePromptConfig commit command prompt preferences.
+type CommitMessagePromptConfig struct {
+	TypeOrder   []string `yaml:"type-order"`
+	RecentFirst bool     `yaml:"recent-first"`
+}
+
+// AllowedTypes types from Types that are not denied by DeniedTypes.
+func (c CommitMessageConfig) AllowedTypes() []string {
+	var types []string
+	for _, t := range c.Types {
+		if !contains(t, c.DeniedTypes) {
+			types = append(types, t)
+		}
+	}
+	return types
 }
 
 // IssueFooterConfig config for issue.
@@ -18,9 +43,39 @@ func (c CommitMessageConfig) IssueFooterConfig() CommitMessageFooterConfig {
 	return CommitMessageFooterConfig{}
 }
 
+// BreakingChangeFooterConfig config for breaking change, if not defined, use "BREAKING CHANGE" as key. Spec keys "BREAKING CHANGE" and "BREAKING-CHANGE" are always accepted as synonyms.
+func (c CommitMessageConfig) BreakingChangeFooterConfig() CommitMessageFooterConfig {
+	cfg := CommitMessageFooterConfig{Key: breakingChangeFooterKey}
+	if v, exists := c.Footer[breakingChangeMetadataKey]; exists && v.Key != "" {
+		cfg = v
+	}
+	synonyms := append([]string{}, cfg.KeySynonyms...)
+	for _, key := range []string{breakingChangeFooterKey, breakingChangeFooterAltKey} {
+		if key != cfg.Key && !contains(key, synonyms) {
+			synonyms = append(synonyms, key)
+		}
+	}
+	cfg.KeySynonyms = synonyms
+	return cfg
+}
+
 // CommitMessageScopeConfig config scope preferences.
 type CommitMessageScopeConfig struct {
-	Values []string `yaml:"values"`
+	Values     []string `yaml:"values"`
+	ValuesFrom string   `yaml:"values-from"`
+	Required   bool     `yaml:"required"`
+}
+
+// CommitMessageSubjectConfig config subject validation.
+type CommitMessageSubjectConfig struct {
+	Lowercase        bool `yaml:"lowercase"`
+	NoTrailingPeriod bool `yaml:"no-trailing-period"`
+}
+
+// CommitMessageBodyConfig config body format.
+type CommitMessageBodyConfig struct {
+	WrapWidth     int `yaml:"wrap-width"`
+	MaxLineLength int `yaml:"max-line-length"`
 }
 
 // CommitMessageFooterConfig config footer metadata.
@@ -28,45 +83,167 @@ type CommitMessageFooterConfig struct {
 	Key            string   `yaml:"key"`
 	KeySynonyms    []string `yaml:"key-synonyms"`
 	UseHash        bool     `yaml:"use-hash"`
+	Separator      string   `yaml:"separator"`
 	AddValuePrefix string   `yaml:"add-value-prefix"`
 }
 
-// CommitMessageIssueConfig issue preferences.
+// footer separator options.
+const (
+	FooterSeparatorColon = ": "
+	FooterSeparatorHash  = " #"
+)
+
+// HasHashSeparator check if footer uses <space># separator, separator has precedence over use-hash.
+func (c CommitMessageFooterConfig) HasHashSeparator() bool {
+	if c.Separator != "" {
+		return c.Separator == FooterSeparatorHash
+	}
+	return c.UseHash
+}
+
+// FooterSeparator separator used between footer key and value, eg.: "Jira: PROJ-123" or "Refs #123".
+func (c CommitMessageFooterConfig) FooterSeparator() string {
+	if c.HasHashSeparator() {
+		return FooterSeparatorHash
+	}
+	return FooterSeparatorColon
+}
+
+// CommitMessageFooterValidationConfig config footer validation, if tokens are defined, only them and configured footer keys are valid.
+type CommitMessageFooterValidationConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Tokens  []string `yaml:"tokens"`
+}
+
+// CommitMessageIssueConfig issue preferences, regex can define multiple values, tried in order, eg.: repositories that migrated issue tracker.
 type CommitMessageIssueConfig struct {
-	Regex string `yaml:"regex"`
+	Regex     StringList `yaml:"regex"`
+	Placement string     `yaml:"placement"`
+}
+
+// issue placement options.
+const (
+	IssuePlacementFooter        = "footer"
+	IssuePlacementSubjectSuffix = "subject-suffix"
+)
+
+// IsSubjectSuffix check if issue should be placed at the end of subject, eg.: "feat: something (#123)".
+func (c CommitMessageIssueConfig) IsSubjectSuffix() bool {
+	return c.Placement == IssuePlacementSubjectSuffix
 }
 
 // ==== Branches ====
 
 // BranchesConfig branches preferences.
 type BranchesConfig struct {
-	PrefixRegex  string   `yaml:"prefix"`
-	SuffixRegex  string   `yaml:"suffix"`
-	DisableIssue bool     `yaml:"disable-issue"`
-	Skip         []string `yaml:"skip"`
-	SkipDetached *bool    `yaml:"skip-detached"`
+	PrefixRegex  string                         `yaml:"prefix"`
+	SuffixRegex  string                         `yaml:"suffix"`
+	DisableIssue bool                           `yaml:"disable-issue"`
+	Skip         []string                       `yaml:"skip"`
+	SkipRegex    []string                       `yaml:"skip-regex"`
+	SkipDetached *bool                          `yaml:"skip-detached"`
+	Trackers     map[string]BranchTrackerConfig `yaml:"trackers"`
+}
+
+// BranchTrackerConfig issue tracker inferred from branch prefix, eg.: "gh" for "gh/42", regex and footer override commit-message issue config.
+type BranchTrackerConfig struct {
+	Regex  StringList                `yaml:"regex"`
+	Footer CommitMessageFooterConfig `yaml:"footer"`
 }
 
 // ==== Versioning ====
 
 // VersioningConfig versioning preferences.
 type VersioningConfig struct {
-	UpdateMajor   []string `yaml:"update-major"`
-	UpdateMinor   []string `yaml:"update-minor"`
-	UpdatePatch   []string `yaml:"update-patch"`
-	IgnoreUnknown bool     `yaml:"ignore-unknown"`
+	UpdateMajor          []string `yaml:"update-major"`
+	UpdateMinor          []string `yaml:"update-minor"`
+	UpdatePatch          []string `yaml:"update-patch"`
+	IgnoreUnknown        bool     `yaml:"ignore-unknown"`
+	CancelReverted       bool     `yaml:"cancel-reverted"`
+	IgnoreBreakingChange bool     `yaml:"ignore-breaking-change"`
+	InitialDevelopment   bool     `yaml:"initial-development"`
 }
 
 // ==== Tag ====
 
 // TagConfig tag preferences.
 type TagConfig struct {
-	Pattern string `yaml:"pattern"`
+	Pattern           string `yaml:"pattern"`
+	UseHighest        bool   `yaml:"use-highest"`
+	MergedOnly        bool   `yaml:"merged-only"`
+	IgnorePrereleases bool   `yaml:"ignore-prereleases"`
+}
+
+// ==== Cache ====
+
+// CacheConfig git log cache preferences, if dir is empty, a dir inside git dir is used.
+type CacheConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Dir     string `yaml:"dir"`
 }
 
 // ==== Release Notes ====
 
 // ReleaseNotesConfig release notes preferences.
 type ReleaseNotesConfig struct {
-	Headers map[string]string `yaml:"headers"`
+	Headers            map[string]string `yaml:"headers"`
+	Template           string            `yaml:"template"`
+	ShowCounts         bool              `yaml:"show-counts"`
+	SquashDuplicates   bool              `yaml:"squash-duplicates"`
+	IssueURL           string            `yaml:"issue-url"`
+	IssueURLs          []IssueURLConfig  `yaml:"issue-urls"`
+	MergeRequestURL    string            `yaml:"merge-request-url"`
+	PullRequestURL     string            `yaml:"pull-request-url"`
+	IncludeTagMessage  bool              `yaml:"include-tag-message"`
+	ShowSummary        bool              `yaml:"show-summary"`
+	SortBy             string            `yaml:"sort-by"`
+	IncludeUnmatched   bool              `yaml:"include-unmatched"`
+	SectionTitleCase   string            `yaml:"section-title-case"`
+	SectionEmoji       map[string]string `yaml:"section-emoji"`
+	DescriptionTrailer string            `yaml:"description-trailer"`
+	EmptyMessage       string            `yaml:"empty-message"`
+	IncludeBody        bool              `yaml:"include-body"`
+	BodyMaxLength      int               `yaml:"body-max-length"`
+	TOC                bool              `yaml:"toc"`
+	HideReverted       bool              `yaml:"hide-reverted"`
+	DateSource         string            `yaml:"date-source"`
+	FeedURL            string            `yaml:"feed-url"`
+	FeedTitle          string            `yaml:"feed-title"`
+	FeedAuthor         string            `yaml:"feed-author"`
+}
+
+// IssueURLConfig url used to link issues matching regex, eg.: jira keys and github numbers on a repo with mixed tracker history.
+type IssueURLConfig struct {
+	Regex string `yaml:"regex"`
+	URL   string `yaml:"url"`
+}
+
+// StringList string list that can be defined as a single value or a list on config.
+type StringList []string
+
+// UnmarshalYAML decode a single value as a list with one item.
+func (l *StringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*l = StringList{}
+		if single != "" {
+			*l = StringList{single}
+		}
+		return nil
+	}
+
+	var items []string
+	if err := unmarshal(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// MarshalYAML encode a list with one item as a single value.
+func (l StringList) MarshalYAML() (interface{}, error) {
+	if len(l) == 1 {
+		return l[0], nil
+	}
+	return []string(l), nil
 }
@@ -2,14 +2,45 @@ package sv
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// case options for release note section titles.
+const (
+	SectionTitleCaseTitle = "title"
+	SectionTitleCaseLower = "lower"
+	SectionTitleCaseUpper = "upper"
+	SectionTitleCaseAsIs  = "as-is"
 )
 
 type releaseNoteTemplateVariables struct {
 	Version         string
 	Date            string
+	TagMessage      string
+	Milestone       string
+	Label           string
 	Sections        map[string]ReleaseNoteSection
 	BreakingChanges BreakingChangeSection
+	Summary         *ReleaseNoteSummary
+	EmptyMessage    string
+}
+
+type formatterTemplates struct {
+	changelog              string
+	releaseNote            string
+	section                string
+	sectionItem            string
+	sectionBreakingChanges string
 }
 
 const (
@@ -21,11 +52,12 @@ const (
 {{- end}}
 `
 
-	rnSectionItem = "- {{if .Message.Scope}}**{{.Message.Scope}}:** {{end}}{{.Message.Description}} ({{.Hash}}){{if .Message.Metadata.issue}} ({{.Message.Metadata.issue}}){{end}}"
+	rnSectionItem = "- {{if .Message.Scope}}**{{.Message.Scope}}:** {{end}}{{.Message.Description}}{{if .Message.PullRequest}} ({{with pullRequestURL .Message.PullRequest}}[#{{$.Message.PullRequest}}]({{.}}){{else}}#{{.Message.PullRequest}}{{end}}){{end}} ({{.Hash}}{{range .SquashedHashes}}, {{.}}{{end}}){{if .Message.Metadata.issue}} ({{with issueURL .Message.Metadata.issue}}[{{$.Message.Metadata.issue}}]({{.}}){{else}}{{.Message.Metadata.issue}}{{end}}){{end}}" +
+		"{{with commitBody .Message.Body}}{{range lines .}}\n  >{{if .}} {{.}}{{end}}{{end}}{{end}}"
 
 	rnSection = `{{- if .}}
 
-### {{.Name}}
+### {{sectionTitle .Name (len .Items)}}
 {{range $k,$v := .Items}}
 {{template "rnSectionItem" $v}}
 {{- end}}
@@ -33,19 +65,218 @@ const (
 
 	rnSectionBreakingChanges = `{{- if ne .Name ""}}
 
-### {{.Name}}
+### {{sectionTitle .Name (len .Messages)}}
 {{range $k,$v := .Messages}}
 - {{$v}}
 {{- end}}
 {{- end}}`
 
-	rnTemplate = `## {{if .Version}}v{{.Version}}{{end}}{{if and .Date .Version}} ({{end}}{{.Date}}{{if and .Version .Date}}){{end}}
+	rnTemplate = `## {{if or .Version .Date}}{{if .Version}}v{{.Version}}{{end}}{{if and .Date .Version}} ({{end}}{{.Date}}{{if and .Version .Date}}){{end}}{{else}}[Unreleased]{{end}}{{if .Milestone}} - {{.Milestone}}{{end}}{{if .Label}} - {{.Label}}{{end}}
+{{- if .TagMessage}}
+
+{{.TagMessage}}
+{{- end}}
 {{- template "rnSection" .Sections.feat}}
 {{- template "rnSection" .Sections.fix}}
+{{- template "rnSection" .Sections.other}}
 {{- template "rnSectionBreakingChanges" .BreakingChanges}}
+{{- if .EmptyMessage}}
+
+{{.EmptyMessage}}
+{{- end}}
+{{- with .Summary}}
+
+{{.Commits}} commit{{if ne .Commits 1}}s{{end}} from {{len .Contributors}} contributor{{if ne (len .Contributors) 1}}s{{end}}
+{{- end}}
 `
 )
 
+const (
+	htmlCglTemplate = `<h1>Changelog</h1>
+{{- range .}}
+
+{{template "rnTemplate" .}}
+<hr>
+{{- end}}
+`
+
+	htmlRnSectionItem = "<li>{{if .Message.Scope}}<strong>{{html .Message.Scope}}:</strong> {{end}}{{html .Message.Description}}{{if .Message.PullRequest}} ({{with pullRequestURL .Message.PullRequest}}<a href=\"{{html .}}\">#{{html $.Message.PullRequest}}</a>{{else}}#{{html .Message.PullRequest}}{{end}}){{end}} ({{html .Hash}}{{range .SquashedHashes}}, {{html .}}{{end}}){{if .Message.Metadata.issue}} ({{with issueURL .Message.Metadata.issue}}<a href=\"{{html .}}\">{{html $.Message.Metadata.issue}}</a>{{else}}{{html .Message.Metadata.issue}}{{end}}){{end}}" +
+		"{{with commitBody .Message.Body}}<blockquote>{{range $i, $l := lines .}}{{if $i}}<br>{{end}}{{html $l}}{{end}}</blockquote>{{end}}</li>"
+
+	htmlRnSection = `{{- if .}}
+
+<h3>{{sectionTitle .Name (len .Items) | html}}</h3>
+<ul>
+{{- range $k,$v := .Items}}
+{{template "rnSectionItem" $v}}
+{{- end}}
+</ul>
+{{- end}}`
+
+	htmlRnSectionBreakingChanges = `{{- if ne .Name ""}}
+
+<h3>{{sectionTitle .Name (len .Messages) | html}}</h3>
+<ul>
+{{- range $k,$v := .Messages}}
+<li>{{html $v}}</li>
+{{- end}}
+</ul>
+{{- end}}`
+
+	htmlRnTemplate = `<h2>{{if or .Version .Date}}{{if .Version}}v{{.Version}}{{end}}{{if and .Date .Version}} ({{end}}{{.Date}}{{if and .Version .Date}}){{end}}{{else}}Unreleased{{end}}{{if .Milestone}} - {{html .Milestone}}{{end}}{{if .Label}} - {{html .Label}}{{end}}</h2>
+{{- if .TagMessage}}
+
+<p>{{html .TagMessage}}</p>
+{{- end}}
+{{- template "rnSection" .Sections.feat}}
+{{- template "rnSection" .Sections.fix}}
+{{- template "rnSection" .Sections.other}}
+{{- template "rnSectionBreakingChanges" .BreakingChanges}}
+{{- if .EmptyMessage}}
+
+<p>{{html .EmptyMessage}}</p>
+{{- end}}
+{{- with .Summary}}
+
+<p>{{.Commits}} commit{{if ne .Commits 1}}s{{end}} from {{len .Contributors}} contributor{{if ne (len .Contributors) 1}}s{{end}}</p>
+{{- end}}
+`
+)
+
+const (
+	slackCglTemplate = `{{- range $i, $v := .}}
+{{- if $i}}
+{{end}}
+{{- template "rnTemplate" $v}}
+{{- end}}`
+
+	slackRnSectionItem = "• {{if .Message.Scope}}*{{mrkdwn .Message.Scope}}:* {{end}}{{mrkdwn .Message.Description}}{{if .Message.PullRequest}} ({{with pullRequestURL .Message.PullRequest}}<{{.}}|#{{$.Message.PullRequest}}>{{else}}#{{.Message.PullRequest}}{{end}}){{end}}{{if .Message.Metadata.issue}} ({{with issueURL .Message.Metadata.issue}}<{{.}}|{{mrkdwn $.Message.Metadata.issue}}>{{else}}{{mrkdwn .Message.Metadata.issue}}{{end}}){{end}}"
+
+	// slack sections list up to 10 items, remaining items are summarized.
+	slackRnSection = `{{- if .}}
+
+*{{sectionTitle .Name (len .Items) | mrkdwn}}*
+{{- range $k,$v := limitItems .Items 10}}
+{{template "rnSectionItem" $v}}
+{{- end}}
+{{- with moreItems .Items 10}}
+• _and {{.}} more_
+{{- end}}
+{{- end}}`
+
+	slackRnSectionBreakingChanges = `{{- if ne .Name ""}}
+
+*{{sectionTitle .Name (len .Messages) | mrkdwn}}*
+{{- range $k,$v := .Messages}}
+• {{mrkdwn $v}}
+{{- end}}
+{{- end}}`
+
+	slackRnTemplate = `*{{if or .Version .Date}}{{if .Version}}v{{.Version}}{{end}}{{if and .Date .Version}} ({{end}}{{.Date}}{{if and .Version .Date}}){{end}}{{else}}Unreleased{{end}}{{if .Milestone}} - {{mrkdwn .Milestone}}{{end}}{{if .Label}} - {{mrkdwn .Label}}{{end}}*
+{{- if .TagMessage}}
+
+{{mrkdwn .TagMessage}}
+{{- end}}
+{{- template "rnSection" .Sections.feat}}
+{{- template "rnSection" .Sections.fix}}
+{{- template "rnSection" .Sections.other}}
+{{- template "rnSectionBreakingChanges" .BreakingChanges}}
+{{- if .EmptyMessage}}
+
+{{mrkdwn .EmptyMessage}}
+{{- end}}
+{{- with .Summary}}
+
+_{{.Commits}} commit{{if ne .Commits 1}}s{{end}} from {{len .Contributors}} contributor{{if ne (len .Contributors) 1}}s{{end}}_
+{{- end}}
+`
+)
+
+const (
+	adocCglTemplate = `= Changelog
+{{- range .}}
+
+{{template "rnTemplate" .}}
+'''
+{{- end}}
+`
+
+	adocRnSectionItem = "* {{if .Message.Scope}}*{{.Message.Scope}}:* {{end}}{{.Message.Description}}{{if .Message.PullRequest}} ({{with pullRequestURL .Message.PullRequest}}link:{{.}}[#{{$.Message.PullRequest}}]{{else}}#{{.Message.PullRequest}}{{end}}){{end}} ({{.Hash}}{{range .SquashedHashes}}, {{.}}{{end}}){{if .Message.Metadata.issue}} ({{with issueURL .Message.Metadata.issue}}link:{{.}}[{{$.Message.Metadata.issue}}]{{else}}{{.Message.Metadata.issue}}{{end}}){{end}}" +
+		"{{with commitBody .Message.Body}}\n+\n____\n{{.}}\n____{{end}}"
+
+	adocRnSection = `{{- if .}}
+
+=== {{sectionTitle .Name (len .Items)}}
+{{range $k,$v := .Items}}
+{{template "rnSectionItem" $v}}
+{{- end}}
+{{- end}}`
+
+	adocRnSectionBreakingChanges = `{{- if ne .Name ""}}
+
+=== {{sectionTitle .Name (len .Messages)}}
+{{range $k,$v := .Messages}}
+* {{$v}}
+{{- end}}
+{{- end}}`
+
+	adocRnTemplate = `== {{if or .Version .Date}}{{if .Version}}v{{.Version}}{{end}}{{if and .Date .Version}} ({{end}}{{.Date}}{{if and .Version .Date}}){{end}}{{else}}Unreleased{{end}}{{if .Milestone}} - {{.Milestone}}{{end}}{{if .Label}} - {{.Label}}{{end}}
+{{- if .TagMessage}}
+
+{{.TagMessage}}
+{{- end}}
+{{- template "rnSection" .Sections.feat}}
+{{- template "rnSection" .Sections.fix}}
+{{- template "rnSection" .Sections.other}}
+{{- template "rnSectionBreakingChanges" .BreakingChanges}}
+{{- if .EmptyMessage}}
+
+{{.EmptyMessage}}
+{{- end}}
+{{- with .Summary}}
+
+{{.Commits}} commit{{if ne .Commits 1}}s{{end}} from {{len .Contributors}} contributor{{if ne (len .Contributors) 1}}s{{end}}
+{{- end}}
+`
+)
+
+// builtInSections sections rendered by built-in release note templates.
+var builtInSections = []string{"feat", "fix", UnmatchedSectionKey}
+
+var (
+	markdownTemplates = formatterTemplates{
+		changelog:              cglTemplate,
+		releaseNote:            rnTemplate,
+		section:                rnSection,
+		sectionItem:            rnSectionItem,
+		sectionBreakingChanges: rnSectionBreakingChanges,
+	}
+
+	htmlTemplates = formatterTemplates{
+		changelog:              htmlCglTemplate,
+		releaseNote:            htmlRnTemplate,
+		section:                htmlRnSection,
+		sectionItem:            htmlRnSectionItem,
+		sectionBreakingChanges: htmlRnSectionBreakingChanges,
+	}
+
+	adocTemplates = formatterTemplates{
+		changelog:              adocCglTemplate,
+		releaseNote:            adocRnTemplate,
+		section:                adocRnSection,
+		sectionItem:            adocRnSectionItem,
+		sectionBreakingChanges: adocRnSectionBreakingChanges,
+	}
+
+	slackTemplates = formatterTemplates{
+		changelog:              slackCglTemplate,
+		releaseNote:            slackRnTemplate,
+		section:                slackRnSection,
+		sectionItem:            slackRnSectionItem,
+		sectionBreakingChanges: slackRnSectionBreakingChanges,
+	}
+)
+
 // OutputFormatter output formatter interface.
 type OutputFormatter interface {
 	FormatReleaseNote(releasenote ReleaseNote) string
@@ -54,24 +285,400 @@ type OutputFormatter interface {
 
 // OutputFormatterImpl formater for release note and changelog.
 type OutputFormatterImpl struct {
+	cfg                 ReleaseNotesConfig
 	releasenoteTemplate *template.Template
 	changelogTemplate   *template.Template
+	toc                 bool
+	renderedSections    []string // sections rendered by release note template, nil if any section can be rendered.
 }
 
 // NewOutputFormatter TemplateProcessor constructor.
-func NewOutputFormatter() *OutputFormatterImpl {
-	cgl := template.Must(template.New("cglTemplate").Parse(cglTemplate))
-	rn := template.Must(cgl.New("rnTemplate").Parse(rnTemplate))
-	template.Must(rn.New("rnSectionItem").Parse(rnSectionItem))
-	template.Must(rn.New("rnSection").Parse(rnSection))
-	template.Must(rn.New("rnSectionBreakingChanges").Parse(rnSectionBreakingChanges))
-	return &OutputFormatterImpl{releasenoteTemplate: rn, changelogTemplate: cgl}
+func NewOutputFormatter(cfg ReleaseNotesConfig) *OutputFormatterImpl {
+	formatter := mustOutputFormatter(newOutputFormatter(cfg, markdownTemplates))
+	formatter.toc = cfg.TOC
+	return formatter
+}
+
+// NewHTMLOutputFormatter TemplateProcessor constructor using html output.
+func NewHTMLOutputFormatter(cfg ReleaseNotesConfig) *OutputFormatterImpl {
+	return mustOutputFormatter(newOutputFormatter(cfg, htmlTemplates))
+}
+
+// NewAsciiDocOutputFormatter TemplateProcessor constructor using asciidoc output.
+func NewAsciiDocOutputFormatter(cfg ReleaseNotesConfig) *OutputFormatterImpl {
+	return mustOutputFormatter(newOutputFormatter(cfg, adocTemplates))
+}
+
+// NewSlackOutputFormatter TemplateProcessor constructor using slack mrkdwn output, sections are truncated to keep it concise.
+func NewSlackOutputFormatter(cfg ReleaseNotesConfig) *OutputFormatterImpl {
+	return mustOutputFormatter(newOutputFormatter(cfg, slackTemplates))
+}
+
+// NewTemplateOutputFormatter TemplateProcessor constructor using a custom release note template, changelog and sections templates are kept as markdown.
+func NewTemplateOutputFormatter(cfg ReleaseNotesConfig, releaseNoteTemplate string) (*OutputFormatterImpl, error) {
+	t := markdownTemplates
+	t.releaseNote = releaseNoteTemplate
+	formatter, err := newOutputFormatter(cfg, t)
+	if err != nil {
+		return nil, err
+	}
+	formatter.toc = cfg.TOC
+	formatter.renderedSections = nil
+	return formatter, nil
+}
+
+func newOutputFormatter(cfg ReleaseNotesConfig, t formatterTemplates) (*OutputFormatterImpl, error) {
+	cgl, err := template.New("cglTemplate").Funcs(templateFuncs(cfg)).Parse(t.changelog)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []struct{ name, content string }{
+		{"rnTemplate", t.releaseNote},
+		{"rnSectionItem", t.sectionItem},
+		{"rnSection", t.section},
+		{"rnSectionBreakingChanges", t.sectionBreakingChanges},
+	}
+	for _, tpl := range templates {
+		if _, err := cgl.New(tpl.name).Parse(tpl.content); err != nil {
+			return nil, err
+		}
+	}
+	return &OutputFormatterImpl{cfg: cfg, releasenoteTemplate: cgl.Lookup("rnTemplate"), changelogTemplate: cgl, renderedSections: builtInSections}, nil
+}
+
+func templateFuncs(cfg ReleaseNotesConfig) template.FuncMap {
+	issueURLs := compileIssueURLs(cfg.IssueURLs)
+	return template.FuncMap{
+		"sectionTitle": func(name string, count int) string {
+			name = titleCase(name, cfg.SectionTitleCase)
+			if cfg.ShowCounts {
+				return fmt.Sprintf("%s (%d)", name, count)
+			}
+			return name
+		},
+		"issueURL": func(issue string) string {
+			if strings.HasPrefix(issue, mergeRequestPrefix) {
+				return referenceURL(cfg.MergeRequestURL, strings.TrimPrefix(issue, mergeRequestPrefix))
+			}
+			for _, u := range issueURLs {
+				if u.regex.MatchString(issue) {
+					return referenceURL(u.url, strings.TrimPrefix(issue, "#"))
+				}
+			}
+			return referenceURL(cfg.IssueURL, strings.TrimPrefix(issue, "#"))
+		},
+		"pullRequestURL": func(number string) string {
+			return referenceURL(cfg.PullRequestURL, number)
+		},
+		"limitItems": func(items []GitCommitLog, n int) []GitCommitLog {
+			if len(items) > n {
+				return items[:n]
+			}
+			return items
+		},
+		"moreItems": func(items []GitCommitLog, n int) int {
+			if len(items) > n {
+				return len(items) - n
+			}
+			return 0
+		},
+		"mrkdwn": mrkdwnEscaper.Replace,
+		"commitBody": func(body string) string {
+			if !cfg.IncludeBody {
+				return ""
+			}
+			return truncate(bodyWithoutFooter(body), cfg.BodyMaxLength)
+		},
+		"lines": func(value string) []string {
+			return strings.Split(value, "\n")
+		},
+	}
+}
+
+// bodyWithoutFooter remove footer paragraph from commit body, eg.: issue and breaking change footers.
+func bodyWithoutFooter(body string) string {
+	body = strings.TrimSpace(body)
+	if footerLines(body) == nil {
+		return body
+	}
+	paragraphs := paragraphSeparatorRegex.Split(body, -1)
+	return strings.TrimSpace(strings.Join(paragraphs[:len(paragraphs)-1], "\n\n"))
+}
+
+// truncate value to max runes, adding "..." if truncated, max <= 0 means no limit.
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if max <= 0 || len(runes) <= max {
+		return value
+	}
+	return strings.TrimSpace(string(runes[:max])) + "..."
+}
+
+// mrkdwnEscaper escape control characters used by slack mrkdwn.
+var mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// capitalizeWords upper case the first letter of each space separated word.
+func capitalizeWords(value string) string {
+	runes := []rune(value)
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			runes[i] = unicode.ToUpper(r)
+		}
+	}
+	return string(runes)
+}
+
+// titleCase change section title case, title is kept as is if option is empty or unknown.
+func titleCase(title, option string) string {
+	switch option {
+	case SectionTitleCaseTitle:
+		return capitalizeWords(strings.ToLower(title))
+	case SectionTitleCaseLower:
+		return strings.ToLower(title)
+	case SectionTitleCaseUpper:
+		return strings.ToUpper(title)
+	default:
+		return title
+	}
+}
+
+type issueURL struct {
+	regex *regexp.Regexp
+	url   string
+}
+
+// compileIssueURLs compile issue url regexes matching the whole issue, invalid regexes are skipped.
+func compileIssueURLs(cfgs []IssueURLConfig) []issueURL {
+	var result []issueURL
+	for _, cfg := range cfgs {
+		if r, err := regexp.Compile("^(?:" + cfg.Regex + ")$"); err == nil {
+			result = append(result, issueURL{regex: r, url: cfg.URL})
+		}
+	}
+	return result
+}
+
+func referenceURL(pattern, id string) string {
+	if pattern == "" {
+		return ""
+	}
+	return fmt.Sprintf(pattern, id)
+}
+
+func mustOutputFormatter(formatter *OutputFormatterImpl, err error) *OutputFormatterImpl {
+	if err != nil {
+		panic(err)
+	}
+	return formatter
+}
+
+// JSONOutputFormatter formatter for release note and changelog using json.
+type JSONOutputFormatter struct{}
+
+// NewJSONOutputFormatter JSONOutputFormatter constructor.
+func NewJSONOutputFormatter() *JSONOutputFormatter {
+	return &JSONOutputFormatter{}
+}
+
+type jsonReleaseNote struct {
+	Version         *semver.Version               `json:"version"`
+	Date            string                        `json:"date,omitempty"`
+	TagMessage      string                        `json:"tagMessage,omitempty"`
+	Milestone       string                        `json:"milestone,omitempty"`
+	Label           string                        `json:"label,omitempty"`
+	Sections        map[string]ReleaseNoteSection `json:"sections"`
+	BreakingChanges BreakingChangeSection         `json:"breakingChanges"`
+	Summary         *ReleaseNoteSummary           `json:"summary,omitempty"`
+}
+
+func toJSONReleaseNote(releasenote ReleaseNote) jsonReleaseNote {
+	var date = ""
+	if !releasenote.Date.IsZero() {
+		date = releasenote.Date.Format("2006-01-02")
+	}
+	return jsonReleaseNote{
+		Version:         releasenote.Version,
+		Date:            date,
+		TagMessage:      releasenote.TagMessage,
+		Milestone:       releasenote.Milestone,
+		Label:           releasenote.Label,
+		Sections:        releasenote.Sections,
+		BreakingChanges: releasenote.BreakingChanges,
+		Summary:         releasenote.Summary,
+	}
+}
+
+// FormatReleaseNote format a release note as json, version is null for ranges.
+func (JSONOutputFormatter) FormatReleaseNote(releasenote ReleaseNote) string {
+	content, _ := json.Marshal(toJSONReleaseNote(releasenote))
+	return string(content)
+}
+
+// FormatChangelog format a changelog as a json array.
+func (JSONOutputFormatter) FormatChangelog(releasenotes []ReleaseNote) string {
+	items := make([]jsonReleaseNote, 0, len(releasenotes))
+	for _, v := range releasenotes {
+		items = append(items, toJSONReleaseNote(v))
+	}
+	content, _ := json.Marshal(items)
+	return string(content)
+}
+
+// TSVOutputFormatter formatter for release note and changelog using tab separated values, one row for each commit.
+type TSVOutputFormatter struct {
+	header bool
+}
+
+// NewTSVOutputFormatter TSVOutputFormatter constructor, if header is true, first row has column names.
+func NewTSVOutputFormatter(header bool) *TSVOutputFormatter {
+	return &TSVOutputFormatter{header: header}
+}
+
+var tsvEscaper = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// FormatReleaseNote format a release note as tsv rows.
+func (p TSVOutputFormatter) FormatReleaseNote(releasenote ReleaseNote) string {
+	return p.FormatChangelog([]ReleaseNote{releasenote})
+}
+
+// FormatChangelog format a changelog as tsv rows with version, date, type, scope, subject, hash and pull request, sections are sorted by type.
+func (p TSVOutputFormatter) FormatChangelog(releasenotes []ReleaseNote) string {
+	var b strings.Builder
+	if p.header {
+		b.WriteString("version\tdate\ttype\tscope\tsubject\thash\tpr\n")
+	}
+	for _, releasenote := range releasenotes {
+		var version, date string
+		if releasenote.Version != nil {
+			version = releasenote.Version.String()
+		}
+		if !releasenote.Date.IsZero() {
+			date = releasenote.Date.Format("2006-01-02")
+		}
+
+		keys := make([]string, 0, len(releasenote.Sections))
+		for key := range releasenote.Sections {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, item := range releasenote.Sections[key].Items {
+				fields := []string{version, date, item.Message.Type, item.Message.Scope, item.Message.Description, item.Hash, item.Message.PullRequest}
+				for i, field := range fields {
+					fields[i] = tsvEscaper.Replace(field)
+				}
+				b.WriteString(strings.Join(fields, "\t") + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+const (
+	atomNamespace    = "http://www.w3.org/2005/Atom"
+	defaultFeedID    = "urn:sv4git:changelog"
+	defaultFeedTitle = "Changelog"
+)
+
+// AtomOutputFormatter formatter for release note and changelog using atom feed, each release note is an entry with html content.
+type AtomOutputFormatter struct {
+	cfg  ReleaseNotesConfig
+	html OutputFormatter
+}
+
+// NewAtomOutputFormatter AtomOutputFormatter constructor.
+func NewAtomOutputFormatter(cfg ReleaseNotesConfig) *AtomOutputFormatter {
+	return &AtomOutputFormatter{cfg: cfg, html: NewHTMLOutputFormatter(cfg)}
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// FormatReleaseNote format a release note as a feed with a single entry.
+func (p AtomOutputFormatter) FormatReleaseNote(releasenote ReleaseNote) string {
+	return p.FormatChangelog([]ReleaseNote{releasenote})
+}
+
+// FormatChangelog format a changelog as a feed, feed is updated on the most recent release note date, feed author is required by atom, if not defined, feed title is used.
+func (p AtomOutputFormatter) FormatChangelog(releasenotes []ReleaseNote) string {
+	feed := atomFeed{XMLNS: atomNamespace, Title: p.cfg.FeedTitle, ID: p.cfg.FeedURL}
+	if feed.Title == "" {
+		feed.Title = defaultFeedTitle
+	}
+	feed.Author.Name = p.cfg.FeedAuthor
+	if feed.Author.Name == "" {
+		feed.Author.Name = feed.Title
+	}
+	if feed.ID == "" {
+		feed.ID = defaultFeedID
+	} else {
+		feed.Link = &atomLink{Rel: "self", Href: p.cfg.FeedURL}
+	}
+
+	var updated time.Time
+	for _, releasenote := range releasenotes {
+		date := releasenote.Date
+		if date.IsZero() {
+			date = time.Now()
+		}
+		if date.After(updated) {
+			updated = date
+		}
+
+		title := "Unreleased"
+		if releasenote.Version != nil {
+			title = "v" + releasenote.Version.String()
+		}
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   title,
+			ID:      feed.ID + "#" + title,
+			Updated: date.Format(time.RFC3339),
+			Content: atomContent{Type: "html", Value: p.html.FormatReleaseNote(releasenote)},
+		})
+	}
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	feed.Updated = updated.Format(time.RFC3339)
+
+	content, _ := xml.MarshalIndent(feed, "", "  ")
+	return xml.Header + string(content)
 }
 
 // FormatReleaseNote format a release note.
 func (p OutputFormatterImpl) FormatReleaseNote(releasenote ReleaseNote) string {
 	var b bytes.Buffer
-	p.releasenoteTemplate.Execute(&b, releaseNoteVariables(releasenote))
+	p.releasenoteTemplate.Execute(&b, p.releaseNoteVariables(releasenote))
 	return b.String()
 }
 
@@ -79,15 +686,60 @@ func (p OutputFormatterImpl) FormatReleaseNote(releasenote ReleaseNote) string {
 func (p OutputFormatterImpl) FormatChangelog(releasenotes []ReleaseNote) string {
 	var templateVars []releaseNoteTemplateVariables
 	for _, v := range releasenotes {
-		templateVars = append(templateVars, releaseNoteVariables(v))
+		templateVars = append(templateVars, p.releaseNoteVariables(v))
 	}
 
 	var b bytes.Buffer
 	p.changelogTemplate.Execute(&b, templateVars)
+	if p.toc {
+		return withTOC(b.String())
+	}
 	return b.String()
 }
 
-func releaseNoteVariables(releasenote ReleaseNote) releaseNoteTemplateVariables {
+var (
+	markdownHeadingRegex = regexp.MustCompile(`^(#{1,6}) +(.+?) *$`)
+	slugInvalidRegex     = regexp.MustCompile(`[^\p{L}\p{M}\p{N}\p{Pc} -]`)
+)
+
+// withTOC insert a table of contents linking to each version heading (level 2) after changelog title.
+// Anchors use same slugs as GitHub, including "-1" suffixes for duplicated headings.
+func withTOC(changelog string) string {
+	lines := strings.Split(changelog, "\n")
+	slugs := make(map[string]int)
+	var toc []string
+	for _, line := range lines {
+		result := markdownHeadingRegex.FindStringSubmatch(line)
+		if result == nil {
+			continue
+		}
+		slug := githubSlug(result[2])
+		if count, exists := slugs[slug]; exists {
+			slugs[slug] = count + 1
+			slug = fmt.Sprintf("%s-%d", slug, count+1)
+		} else {
+			slugs[slug] = 0
+		}
+		if len(result[1]) == 2 {
+			toc = append(toc, fmt.Sprintf("- [%s](#%s)", result[2], slug))
+		}
+	}
+	if len(toc) == 0 {
+		return changelog
+	}
+
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "# ") {
+		return lines[0] + "\n\n" + strings.Join(toc, "\n") + "\n" + strings.Join(lines[1:], "\n")
+	}
+	return strings.Join(toc, "\n") + "\n\n" + changelog
+}
+
+// githubSlug heading anchor as generated by GitHub, eg.: "v1.0.0 (2020-05-01)" to "v100-2020-05-01".
+func githubSlug(heading string) string {
+	return strings.ReplaceAll(slugInvalidRegex.ReplaceAllString(strings.ToLower(heading), ""), " ", "-")
+}
+
+func (p OutputFormatterImpl) releaseNoteVariables(releasenote ReleaseNote) releaseNoteTemplateVariables {
 	var date = ""
 	if !releasenote.Date.IsZero() {
 		date = releasenote.Date.Format("2006-01-02")
@@ -97,10 +749,61 @@ func releaseNoteVariables(releasenote ReleaseNote) releaseNoteTemplateVariables
 	if releasenote.Version != nil {
 		version = releasenote.Version.String()
 	}
+	var tagMessage = ""
+	if p.cfg.IncludeTagMessage {
+		tagMessage = releasenote.TagMessage
+	}
+	var emptyMessage = ""
+	if !p.hasEntries(releasenote) {
+		emptyMessage = p.cfg.EmptyMessage
+	}
 	return releaseNoteTemplateVariables{
 		Version:         version,
 		Date:            date,
-		Sections:        releasenote.Sections,
-		BreakingChanges: releasenote.BreakingChanges,
+		TagMessage:      tagMessage,
+		Milestone:       releasenote.Milestone,
+		Label:           releasenote.Label,
+		Sections:        p.sectionsWithEmoji(releasenote.Sections),
+		BreakingChanges: p.breakingChangesWithEmoji(releasenote.BreakingChanges),
+		Summary:         releasenote.Summary,
+		EmptyMessage:    emptyMessage,
+	}
+}
+
+// hasEntries check if release note has breaking changes or items on sections rendered by release note template.
+func (p OutputFormatterImpl) hasEntries(releasenote ReleaseNote) bool {
+	if len(releasenote.BreakingChanges.Messages) > 0 {
+		return true
+	}
+	for key, section := range releasenote.Sections {
+		if len(section.Items) > 0 && (p.renderedSections == nil || contains(key, p.renderedSections)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionsWithEmoji prefix section names with configured emoji for its type, sections are copied to keep release note unchanged.
+func (p OutputFormatterImpl) sectionsWithEmoji(sections map[string]ReleaseNoteSection) map[string]ReleaseNoteSection {
+	if len(p.cfg.SectionEmoji) == 0 {
+		return sections
+	}
+	result := make(map[string]ReleaseNoteSection, len(sections))
+	for key, section := range sections {
+		section.Name = withEmoji(p.cfg.SectionEmoji[key], section.Name)
+		result[key] = section
+	}
+	return result
+}
+
+func (p OutputFormatterImpl) breakingChangesWithEmoji(section BreakingChangeSection) BreakingChangeSection {
+	section.Name = withEmoji(p.cfg.SectionEmoji[breakingChangeMetadataKey], section.Name)
+	return section
+}
+
+func withEmoji(emoji, name string) string {
+	if emoji == "" || name == "" {
+		return name
 	}
+	return emoji + " " + name
 }
@@ -1,6 +1,7 @@
 package sv
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +14,8 @@ var emptyDateChangelog = `## v1.0.0
 `
 var emptyVersionChangelog = `## 2020-05-01
 `
+var unreleasedChangelog = `## [Unreleased]
+`
 
 func TestOutputFormatterImpl_FormatReleaseNote(t *testing.T) {
 	date, _ := time.Parse("2006-01-02", "2020-05-01")
@@ -25,10 +28,11 @@ func TestOutputFormatterImpl_FormatReleaseNote(t *testing.T) {
 		{"with date", emptyReleaseNote("1.0.0", date.Truncate(time.Minute)), dateChangelog},
 		{"without date", emptyReleaseNote("1.0.0", time.Time{}.Truncate(time.Minute)), emptyDateChangelog},
 		{"without version", emptyReleaseNote("", date.Truncate(time.Minute)), emptyVersionChangelog},
+		{"without version and date", emptyReleaseNote("", time.Time{}), unreleasedChangelog},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewOutputFormatter().FormatReleaseNote(tt.input); got != tt.want {
+			if got := NewOutputFormatter(ReleaseNotesConfig{}).FormatReleaseNote(tt.input); got != tt.want {
 				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
 			}
 		})
@@ -45,3 +49,636 @@ func emptyReleaseNote(version string, date time.Time) ReleaseNote {
 		Date:    date,
 	}
 }
+
+var htmlReleaseNote = `<h2>v1.0.0 (2020-05-01)</h2>
+
+<h3>Features</h3>
+<ul>
+<li><strong>ui:</strong> render &lt;b&gt; tags &amp; quotes (a1b2c3d)</li>
+</ul>
+`
+
+func TestOutputFormatterImpl_FormatReleaseNote_HTML(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	commit := GitCommitLog{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Scope: "ui", Description: "render <b> tags & quotes", Metadata: map[string]string{}}}
+
+	tests := []struct {
+		name  string
+		input ReleaseNote
+		want  string
+	}{
+		{"escaped description", releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commit})}, nil), htmlReleaseNote},
+		{"without sections", emptyReleaseNote("1.0.0", date.Truncate(time.Minute)), "<h2>v1.0.0 (2020-05-01)</h2>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewHTMLOutputFormatter(ReleaseNotesConfig{}).FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var adocReleaseNote = `== v1.0.0 (2020-05-01)
+
+=== Features
+
+* *ui:* add button (a1b2c3d) (link:https://example.com/issues/12[#12])
+`
+
+func TestOutputFormatterImpl_FormatReleaseNote_AsciiDoc(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	commit := GitCommitLog{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Scope: "ui", Description: "add button", Metadata: map[string]string{"issue": "#12"}}}
+
+	tests := []struct {
+		name  string
+		input ReleaseNote
+		want  string
+	}{
+		{"with issue link", releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commit})}, nil), adocReleaseNote},
+		{"unreleased", emptyReleaseNote("", time.Time{}), "== Unreleased\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAsciiDocOutputFormatter(ReleaseNotesConfig{IssueURL: "https://example.com/issues/%s"}).FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateOutputFormatter(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+
+	tests := []struct {
+		name     string
+		template string
+		input    ReleaseNote
+		want     string
+		wantErr  bool
+	}{
+		{"custom header", "Release {{.Version}} - {{.Date}}", emptyReleaseNote("1.0.0", date), "Release 1.0.0 - 2020-05-01", false},
+		{"builtin sections", "# {{.Version}}{{template \"rnSection\" .Sections.feat}}", releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commitlog("feat", map[string]string{})})}, nil), "# 1.0.0\n\n### Features\n\n- subject text ()", false},
+		{"invalid template", "{{.Version", emptyReleaseNote("1.0.0", date), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := NewTemplateOutputFormatter(ReleaseNotesConfig{}, tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTemplateOutputFormatter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got := formatter.FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var countsReleaseNote = `## v1.0.0 (2020-05-01)
+
+### Features (2)
+
+- subject text ()
+- subject text ()
+
+### Breaking Changes (1)
+
+- breaks
+`
+
+func TestOutputFormatterImpl_FormatReleaseNote_Slack(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	commit := func(description string) GitCommitLog {
+		return GitCommitLog{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Scope: "ui", Description: description, Metadata: map[string]string{}}}
+	}
+	commits := func(n int) []GitCommitLog {
+		items := make([]GitCommitLog, n)
+		for i := range items {
+			items[i] = commit("item")
+		}
+		return items
+	}
+
+	tests := []struct {
+		name  string
+		input ReleaseNote
+		want  string
+	}{
+		{"escaped description", releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commit("render <b> tags & quotes")})}, []string{"breaks"}), "*v1.0.0 (2020-05-01)*\n\n*Features*\n• *ui:* render &lt;b&gt; tags &amp; quotes\n\n*Breaking Changes*\n• breaks\n"},
+		{"truncated section", releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", commits(12))}, nil), "*v1.0.0 (2020-05-01)*\n\n*Features*\n" + strings.Repeat("• *ui:* item\n", 10) + "• _and 2 more_\n"},
+		{"without sections", emptyReleaseNote("1.0.0", date.Truncate(time.Minute)), "*v1.0.0 (2020-05-01)*\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSlackOutputFormatter(ReleaseNotesConfig{}).FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_EmptyMessage(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	cfg := ReleaseNotesConfig{EmptyMessage: "No changes."}
+	withSection := releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commitlog("feat", map[string]string{})})}, nil)
+	withDocs := releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"docs": newReleaseNoteSection("Docs", []GitCommitLog{commitlog("docs", map[string]string{})})}, nil)
+	customTemplate, err := NewTemplateOutputFormatter(cfg, "v{{.Version}}{{with .Sections.docs}} docs{{end}}{{.EmptyMessage}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		formatter OutputFormatter
+		input     ReleaseNote
+		want      string
+	}{
+		{"markdown empty release", NewOutputFormatter(cfg), emptyReleaseNote("1.0.0", date.Truncate(time.Minute)), "## v1.0.0 (2020-05-01)\n\nNo changes.\n"},
+		{"html empty release", NewHTMLOutputFormatter(cfg), emptyReleaseNote("1.0.0", date.Truncate(time.Minute)), "<h2>v1.0.0 (2020-05-01)</h2>\n\n<p>No changes.</p>\n"},
+		{"slack empty release", NewSlackOutputFormatter(cfg), emptyReleaseNote("1.0.0", date.Truncate(time.Minute)), "*v1.0.0 (2020-05-01)*\n\nNo changes.\n"},
+		{"breaking changes only", NewOutputFormatter(cfg), releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{}, []string{"breaks"}), "## v1.0.0 (2020-05-01)\n\n### Breaking Changes\n\n- breaks\n"},
+		{"release with sections", NewOutputFormatter(cfg), withSection, "## v1.0.0 (2020-05-01)\n\n### Features\n\n- subject text ()\n"},
+		{"only sections not rendered", NewOutputFormatter(cfg), withDocs, "## v1.0.0 (2020-05-01)\n\nNo changes.\n"},
+		{"custom template with section not rendered by default", customTemplate, withDocs, "v1.0.0 docs"},
+		{"empty message disabled", NewOutputFormatter(ReleaseNotesConfig{}), emptyReleaseNote("1.0.0", date.Truncate(time.Minute)), "## v1.0.0 (2020-05-01)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_ShowCounts(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	input := releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commitlog("feat", map[string]string{}), commitlog("feat", map[string]string{})})}, []string{"breaks"})
+
+	if got := NewOutputFormatter(ReleaseNotesConfig{ShowCounts: true}).FormatReleaseNote(input); got != countsReleaseNote {
+		t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, countsReleaseNote)
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_SectionTitleCase(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	input := releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("New Features", []GitCommitLog{commitlog("feat", map[string]string{})})}, []string{"breaks"})
+	releaseNote := func(features, breaking string) string {
+		return "## v1.0.0 (2020-05-01)\n\n### " + features + "\n\n- subject text ()\n\n### " + breaking + "\n\n- breaks\n"
+	}
+
+	tests := []struct {
+		name   string
+		option string
+		want   string
+	}{
+		{"default", "", releaseNote("New Features", "Breaking Changes")},
+		{"as is", SectionTitleCaseAsIs, releaseNote("New Features", "Breaking Changes")},
+		{"lower", SectionTitleCaseLower, releaseNote("new features", "breaking changes")},
+		{"upper", SectionTitleCaseUpper, releaseNote("NEW FEATURES", "BREAKING CHANGES")},
+		{"title", SectionTitleCaseTitle, releaseNote("New Features", "Breaking Changes")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOutputFormatter(ReleaseNotesConfig{SectionTitleCase: tt.option}).FormatReleaseNote(input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_SectionEmoji(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	input := releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{
+		"feat": newReleaseNoteSection("Features", []GitCommitLog{commitlog("feat", map[string]string{})}),
+		"fix":  newReleaseNoteSection("Bug Fixes", []GitCommitLog{commitlog("fix", map[string]string{})}),
+	}, []string{"breaks"})
+
+	tests := []struct {
+		name  string
+		emoji map[string]string
+		want  string
+	}{
+		{"without emoji", nil, "## v1.0.0 (2020-05-01)\n\n### Features\n\n- subject text ()\n\n### Bug Fixes\n\n- subject text ()\n\n### Breaking Changes\n\n- breaks\n"},
+		{"with emoji", map[string]string{"feat": "✨", "breaking-change": "💥"}, "## v1.0.0 (2020-05-01)\n\n### ✨ Features\n\n- subject text ()\n\n### Bug Fixes\n\n- subject text ()\n\n### 💥 Breaking Changes\n\n- breaks\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOutputFormatter(ReleaseNotesConfig{SectionEmoji: tt.emoji}).FormatReleaseNote(input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+			if input.Sections["feat"].Name != "Features" {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() changed release note section name to %v", input.Sections["feat"].Name)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_Milestone(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	input := releaseNote(semver.MustParse("1.0.0"), date, nil, nil)
+	input.Milestone = "Q3 <Launch>"
+
+	tests := []struct {
+		name      string
+		formatter OutputFormatter
+		want      string
+	}{
+		{"markdown", NewOutputFormatter(ReleaseNotesConfig{}), "## v1.0.0 (2020-05-01) - Q3 <Launch>\n"},
+		{"html", NewHTMLOutputFormatter(ReleaseNotesConfig{}), "<h2>v1.0.0 (2020-05-01) - Q3 &lt;Launch&gt;</h2>\n"},
+		{"slack", NewSlackOutputFormatter(ReleaseNotesConfig{}), "*v1.0.0 (2020-05-01) - Q3 &lt;Launch&gt;*\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.FormatReleaseNote(input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_Label(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	input := releaseNote(nil, date, nil, nil)
+	input.Label = "3 <commits>"
+
+	tests := []struct {
+		name      string
+		formatter OutputFormatter
+		want      string
+	}{
+		{"markdown", NewOutputFormatter(ReleaseNotesConfig{}), "## 2020-05-01 - 3 <commits>\n"},
+		{"html", NewHTMLOutputFormatter(ReleaseNotesConfig{}), "<h2>2020-05-01 - 3 &lt;commits&gt;</h2>\n"},
+		{"slack", NewSlackOutputFormatter(ReleaseNotesConfig{}), "*2020-05-01 - 3 &lt;commits&gt;*\n"},
+		{"json", NewJSONOutputFormatter(), `{"version":null,"date":"2020-05-01","label":"3 \u003ccommits\u003e","sections":null,"breakingChanges":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.FormatReleaseNote(input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_IssueLinks(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	cfg := ReleaseNotesConfig{IssueURL: "https://gitlab.com/group/project/-/issues/%s", MergeRequestURL: "https://gitlab.com/group/project/-/merge_requests/%s"}
+	mixedCfg := cfg
+	mixedCfg.IssueURLs = []IssueURLConfig{{Regex: "[A-Z]+-[0-9]+", URL: "https://jira.example.com/browse/%s"}, {Regex: "[0-9", URL: "https://invalid.example.com/%s"}}
+	commit := func(issue string) GitCommitLog {
+		return GitCommitLog{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Description: "something", Metadata: map[string]string{issueMetadataKey: issue}}}
+	}
+	input := func(issue string) ReleaseNote {
+		return releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commit(issue)})}, nil)
+	}
+
+	tests := []struct {
+		name      string
+		formatter OutputFormatter
+		input     ReleaseNote
+		want      string
+	}{
+		{"issue link", NewOutputFormatter(cfg), input("#123"), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d) ([#123](https://gitlab.com/group/project/-/issues/123))\n"},
+		{"merge request link", NewOutputFormatter(cfg), input("!45"), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d) ([!45](https://gitlab.com/group/project/-/merge_requests/45))\n"},
+		{"without url", NewOutputFormatter(ReleaseNotesConfig{}), input("#123"), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d) (#123)\n"},
+		{"issue url by regex", NewOutputFormatter(mixedCfg), input("PROJ-1"), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d) ([PROJ-1](https://jira.example.com/browse/PROJ-1))\n"},
+		{"issue url fallback", NewOutputFormatter(mixedCfg), input("#123"), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d) ([#123](https://gitlab.com/group/project/-/issues/123))\n"},
+		{"html issue link", NewHTMLOutputFormatter(cfg), input("#123"), "<h2>v1.0.0 (2020-05-01)</h2>\n\n<h3>Features</h3>\n<ul>\n<li>something (a1b2c3d) (<a href=\"https://gitlab.com/group/project/-/issues/123\">#123</a>)</li>\n</ul>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_IncludeBody(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	input := func(body string) ReleaseNote {
+		commit := GitCommitLog{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Description: "something", Body: body}}
+		return releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commit})}, nil)
+	}
+	body := "run <migration> first\n\nthen restart\n\njira: JIRA-123"
+
+	tests := []struct {
+		name      string
+		formatter OutputFormatter
+		input     ReleaseNote
+		want      string
+	}{
+		{"disabled", NewOutputFormatter(ReleaseNotesConfig{}), input(body), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d)\n"},
+		{"markdown", NewOutputFormatter(ReleaseNotesConfig{IncludeBody: true}), input(body), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d)\n  > run <migration> first\n  >\n  > then restart\n"},
+		{"footer only", NewOutputFormatter(ReleaseNotesConfig{IncludeBody: true}), input("jira: JIRA-123"), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d)\n"},
+		{"truncated", NewOutputFormatter(ReleaseNotesConfig{IncludeBody: true, BodyMaxLength: 9}), input(body), "## v1.0.0 (2020-05-01)\n\n### Features\n\n- something (a1b2c3d)\n  > run <migr...\n"},
+		{"html", NewHTMLOutputFormatter(ReleaseNotesConfig{IncludeBody: true}), input(body), "<h2>v1.0.0 (2020-05-01)</h2>\n\n<h3>Features</h3>\n<ul>\n<li>something (a1b2c3d)<blockquote>run &lt;migration&gt; first<br><br>then restart</blockquote></li>\n</ul>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_PullRequest(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	commit := GitCommitLog{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Description: "add button", PullRequest: "123", Metadata: map[string]string{}}}
+	rn := releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commit})}, nil)
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"without url", "", "- add button (#123) (a1b2c3d)"},
+		{"with url", "https://github.com/owner/repo/pull/%s", "- add button ([#123](https://github.com/owner/repo/pull/123)) (a1b2c3d)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOutputFormatter(ReleaseNotesConfig{PullRequestURL: tt.url}).FormatReleaseNote(rn); !strings.Contains(got, tt.want) {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_SquashedHashes(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	commit := GitCommitLog{Hash: "a1b2c3d", SquashedHashes: []string{"e4f5a6b"}, Message: CommitMessage{Type: "feat", Description: "add button", Metadata: map[string]string{}}}
+	rn := releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commit})}, nil)
+
+	if got := NewOutputFormatter(ReleaseNotesConfig{}).FormatReleaseNote(rn); !strings.Contains(got, "- add button (a1b2c3d, e4f5a6b)") {
+		t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want squashed hashes", got)
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_TagMessage(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	input := emptyReleaseNote("1.0.0", date)
+	input.TagMessage = "Version 1.0.0"
+
+	tests := []struct {
+		name string
+		cfg  ReleaseNotesConfig
+		want string
+	}{
+		{"include tag message", ReleaseNotesConfig{IncludeTagMessage: true}, "## v1.0.0 (2020-05-01)\n\nVersion 1.0.0\n"},
+		{"ignore tag message", ReleaseNotesConfig{}, dateChangelog},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOutputFormatter(tt.cfg).FormatReleaseNote(input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatReleaseNote_Summary(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	withSummary := func(summary *ReleaseNoteSummary) ReleaseNote {
+		rn := emptyReleaseNote("1.0.0", date)
+		rn.Summary = summary
+		return rn
+	}
+
+	tests := []struct {
+		name  string
+		input ReleaseNote
+		want  string
+	}{
+		{"plural", withSummary(&ReleaseNoteSummary{Commits: 23, Contributors: []string{"a", "b"}}), "## v1.0.0 (2020-05-01)\n\n23 commits from 2 contributors\n"},
+		{"singular", withSummary(&ReleaseNoteSummary{Commits: 1, Contributors: []string{"a"}}), "## v1.0.0 (2020-05-01)\n\n1 commit from 1 contributor\n"},
+		{"without summary", withSummary(nil), dateChangelog},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOutputFormatter(ReleaseNotesConfig{}).FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputFormatterImpl_FormatChangelog_TOC(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	notes := []ReleaseNote{emptyReleaseNote("", time.Time{}), emptyReleaseNote("1.0.0", date), emptyReleaseNote("1.0.0", date)}
+
+	want := "# Changelog\n\n- [[Unreleased]](#unreleased)\n- [v1.0.0 (2020-05-01)](#v100-2020-05-01)\n- [v1.0.0 (2020-05-01)](#v100-2020-05-01-1)\n"
+	got := NewOutputFormatter(ReleaseNotesConfig{TOC: true}).FormatChangelog(notes)
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("OutputFormatterImpl.FormatChangelog() = %v, want prefix %v", got, want)
+	}
+	if strings.Contains(NewOutputFormatter(ReleaseNotesConfig{}).FormatChangelog(notes), "](#") {
+		t.Errorf("OutputFormatterImpl.FormatChangelog() should not render toc when disabled")
+	}
+}
+
+func Test_githubSlug(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{"v1.0.0 (2020-05-01)", "v100-2020-05-01"},
+		{"[Unreleased]", "unreleased"},
+		{"Breaking Changes", "breaking-changes"},
+		{"snake_case & Ünïcode", "snake_case--ünïcode"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			if got := githubSlug(tt.heading); got != tt.want {
+				t.Errorf("githubSlug() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_titleCase(t *testing.T) {
+	tests := []struct {
+		title  string
+		option string
+		want   string
+	}{
+		{"BREAKING CHANGES", SectionTitleCaseTitle, "Breaking Changes"},
+		{"bug fixes", SectionTitleCaseTitle, "Bug Fixes"},
+		{"it's done", SectionTitleCaseTitle, "It's Done"},
+		{"élan vital", SectionTitleCaseTitle, "Élan Vital"},
+		{"Bug Fixes", SectionTitleCaseLower, "bug fixes"},
+		{"Bug Fixes", SectionTitleCaseUpper, "BUG FIXES"},
+		{"Bug Fixes", "", "Bug Fixes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title+" "+tt.option, func(t *testing.T) {
+			if got := titleCase(tt.title, tt.option); got != tt.want {
+				t.Errorf("titleCase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONOutputFormatter_FormatReleaseNote(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	sections := map[string]ReleaseNoteSection{"feat": {Name: "Features", Items: []GitCommitLog{{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Description: "something"}}}}}
+
+	tests := []struct {
+		name  string
+		input ReleaseNote
+		want  string
+	}{
+		{"with version", ReleaseNote{Version: semver.MustParse("1.0.0"), Date: date, Sections: sections}, `{"version":"1.0.0","date":"2020-05-01","sections":{"feat":{"name":"Features","items":[{"hash":"a1b2c3d","message":{"type":"feat","description":"something"}}]}},"breakingChanges":{}}`},
+		{"range without version", ReleaseNote{Date: date, Sections: sections}, `{"version":null,"date":"2020-05-01","sections":{"feat":{"name":"Features","items":[{"hash":"a1b2c3d","message":{"type":"feat","description":"something"}}]}},"breakingChanges":{}}`},
+		{"breaking changes", ReleaseNote{BreakingChanges: BreakingChangeSection{Name: "Breaking Changes", Messages: []string{"breaks"}}}, `{"version":null,"sections":null,"breakingChanges":{"name":"Breaking Changes","messages":["breaks"]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewJSONOutputFormatter().FormatReleaseNote(tt.input); got != tt.want {
+				t.Errorf("JSONOutputFormatter.FormatReleaseNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAtomOutputFormatter_FormatChangelog(t *testing.T) {
+	older, _ := time.Parse("2006-01-02", "2020-05-01")
+	newer, _ := time.Parse("2006-01-02", "2020-06-01")
+	sections := map[string]ReleaseNoteSection{"feat": {Name: "Features", Items: []GitCommitLog{{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Description: "use <b> & more"}}}}}
+	input := []ReleaseNote{
+		{Version: semver.MustParse("1.1.0"), Date: newer, Sections: sections},
+		{Version: semver.MustParse("1.0.0"), Date: older},
+	}
+	content := "&lt;h2&gt;v1.1.0 (2020-06-01)&lt;/h2&gt;&#xA;&#xA;&lt;h3&gt;Features&lt;/h3&gt;&#xA;&lt;ul&gt;&#xA;&lt;li&gt;use &amp;lt;b&amp;gt; &amp;amp; more (a1b2c3d)&lt;/li&gt;&#xA;&lt;/ul&gt;&#xA;"
+
+	tests := []struct {
+		name string
+		cfg  ReleaseNotesConfig
+		want string
+	}{
+		{"default feed", ReleaseNotesConfig{}, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Changelog</title>
+  <id>urn:sv4git:changelog</id>
+  <updated>2020-06-01T00:00:00Z</updated>
+  <author>
+    <name>Changelog</name>
+  </author>
+  <entry>
+    <title>v1.1.0</title>
+    <id>urn:sv4git:changelog#v1.1.0</id>
+    <updated>2020-06-01T00:00:00Z</updated>
+    <content type="html">` + content + `</content>
+  </entry>
+  <entry>
+    <title>v1.0.0</title>
+    <id>urn:sv4git:changelog#v1.0.0</id>
+    <updated>2020-05-01T00:00:00Z</updated>
+    <content type="html">&lt;h2&gt;v1.0.0 (2020-05-01)&lt;/h2&gt;&#xA;</content>
+  </entry>
+</feed>`},
+		{"feed url and title", ReleaseNotesConfig{FeedURL: "https://example.com/changelog.xml", FeedTitle: "Project & Co"}, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Project &amp; Co</title>
+  <id>https://example.com/changelog.xml</id>
+  <updated>2020-06-01T00:00:00Z</updated>
+  <author>
+    <name>Project &amp; Co</name>
+  </author>
+  <link rel="self" href="https://example.com/changelog.xml"></link>
+  <entry>
+    <title>v1.1.0</title>
+    <id>https://example.com/changelog.xml#v1.1.0</id>
+    <updated>2020-06-01T00:00:00Z</updated>
+    <content type="html">` + content + `</content>
+  </entry>
+  <entry>
+    <title>v1.0.0</title>
+    <id>https://example.com/changelog.xml#v1.0.0</id>
+    <updated>2020-05-01T00:00:00Z</updated>
+    <content type="html">&lt;h2&gt;v1.0.0 (2020-05-01)&lt;/h2&gt;&#xA;</content>
+  </entry>
+</feed>`},
+		{"feed author", ReleaseNotesConfig{FeedAuthor: "Release Team"}, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Changelog</title>
+  <id>urn:sv4git:changelog</id>
+  <updated>2020-06-01T00:00:00Z</updated>
+  <author>
+    <name>Release Team</name>
+  </author>
+  <entry>
+    <title>v1.1.0</title>
+    <id>urn:sv4git:changelog#v1.1.0</id>
+    <updated>2020-06-01T00:00:00Z</updated>
+    <content type="html">` + content + `</content>
+  </entry>
+  <entry>
+    <title>v1.0.0</title>
+    <id>urn:sv4git:changelog#v1.0.0</id>
+    <updated>2020-05-01T00:00:00Z</updated>
+    <content type="html">&lt;h2&gt;v1.0.0 (2020-05-01)&lt;/h2&gt;&#xA;</content>
+  </entry>
+</feed>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAtomOutputFormatter(tt.cfg).FormatChangelog(input); got != tt.want {
+				t.Errorf("AtomOutputFormatter.FormatChangelog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAtomOutputFormatter_FormatChangelog_PullRequest(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	commit := GitCommitLog{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Description: "add button", PullRequest: "123", Metadata: map[string]string{}}}
+	input := []ReleaseNote{releaseNote(semver.MustParse("1.0.0"), date, map[string]ReleaseNoteSection{"feat": newReleaseNoteSection("Features", []GitCommitLog{commit})}, nil)}
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"without url", "", "add button (#123)"},
+		{"with url", "https://github.com/org/repo/pull/%s", "add button (&lt;a href=&#34;https://github.com/org/repo/pull/123&#34;&gt;#123&lt;/a&gt;)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAtomOutputFormatter(ReleaseNotesConfig{PullRequestURL: tt.url}).FormatChangelog(input); !strings.Contains(got, tt.want) {
+				t.Errorf("AtomOutputFormatter.FormatChangelog() = %v, want to contain %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTSVOutputFormatter_FormatChangelog(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	sections := map[string]ReleaseNoteSection{
+		"fix":  {Name: "Bug Fixes", Items: []GitCommitLog{{Hash: "e4f5a6b", Message: CommitMessage{Type: "fix", Description: "tab\tand\nnew line"}}}},
+		"feat": {Name: "Features", Items: []GitCommitLog{{Hash: "a1b2c3d", Message: CommitMessage{Type: "feat", Scope: "api", Description: "something", PullRequest: "123"}}}},
+	}
+	input := []ReleaseNote{{Version: semver.MustParse("1.0.0"), Date: date, Sections: sections}, {Sections: sections}}
+	rows := "1.0.0\t2020-05-01\tfeat\tapi\tsomething\ta1b2c3d\t123\n1.0.0\t2020-05-01\tfix\t\ttab and new line\te4f5a6b\t\n\t\tfeat\tapi\tsomething\ta1b2c3d\t123\n\t\tfix\t\ttab and new line\te4f5a6b\t\n"
+
+	tests := []struct {
+		name   string
+		header bool
+		want   string
+	}{
+		{"with header", true, "version\tdate\ttype\tscope\tsubject\thash\tpr\n" + rows},
+		{"without header", false, rows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTSVOutputFormatter(tt.header).FormatChangelog(input); got != tt.want {
+				t.Errorf("TSVOutputFormatter.FormatChangelog() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,17 @@ package sv
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,30 +23,53 @@ import (
 const (
 	logSeparator = "##"
 	endLine      = "~~"
+	// nulEndLine record separator for outputs that may contain endLine, eg.: tag messages.
+	nulEndLine = "\x00"
 )
 
 // Git commands
 type Git interface {
 	LastTag() string
+	LastPrefixedTag(prefix, ref string) string
+	LastTagWithPrereleases(prefix, ref string) string
 	Log(lr LogRange) ([]GitCommitLog, error)
-	Commit(header, body, footer string) error
-	Tag(version semver.Version) error
+	RawCommits(ref string, exclude ...string) ([]GitRawCommit, error)
+	Commit(header, body, footer string, sign bool) error
+	Tag(version semver.Version, ref string) error
+	TagName(version semver.Version) string
+	DeleteTag(tag string, push bool) error
 	Tags() ([]GitTag, error)
 	Branch() string
 	IsDetached() (bool, error)
+	IsDirty() (bool, error)
+	FetchTags(remote string) error
+	ConfigBool(key string) bool
+	HasCommit(hash string) bool
 }
 
-// GitCommitLog description of a single commit log
+// GitCommitLog description of a single commit log, squashed hashes are duplicated commits collapsed into this one on release notes.
 type GitCommitLog struct {
-	Date    string        `json:"date,omitempty"`
-	Hash    string        `json:"hash,omitempty"`
-	Message CommitMessage `json:"message,omitempty"`
+	Date           string        `json:"date,omitempty"`
+	CommitterDate  string        `json:"committerDate,omitempty"`
+	Hash           string        `json:"hash,omitempty"`
+	AuthorName     string        `json:"authorName,omitempty"`
+	AuthorEmail    string        `json:"authorEmail,omitempty"`
+	Message        CommitMessage `json:"message,omitempty"`
+	SquashedHashes []string      `json:"squashedHashes,omitempty"`
+}
+
+// GitRawCommit commit hash and full message, as written by the author.
+type GitRawCommit struct {
+	Hash    string
+	Message string
 }
 
 // GitTag git tag info
 type GitTag struct {
-	Name string
-	Date time.Time
+	Name     string
+	Date     time.Time
+	Message  string
+	tagOfTag bool // tag pointing to another tag, its date is replaced by target commit date.
 }
 
 // LogRangeType type of log range
@@ -57,42 +87,215 @@ type LogRange struct {
 	rangeType LogRangeType
 	start     string
 	end       string
+	paths     []string
+	limit     int
+}
+
+// NewLogRange LogRange constructor, if paths are defined, only commits touching them will be used.
+func NewLogRange(t LogRangeType, start, end string, paths ...string) LogRange {
+	return LogRange{rangeType: t, start: start, end: end, paths: paths}
 }
 
-// NewLogRange LogRange constructor
-func NewLogRange(t LogRangeType, start, end string) LogRange {
-	return LogRange{rangeType: t, start: start, end: end}
+// WithLimit return a copy of LogRange limited to the newest n commits, if n is zero or less, commits are not limited.
+func (lr LogRange) WithLimit(n int) LogRange {
+	lr.limit = n
+	return lr
 }
 
 // GitImpl git command implementation
 type GitImpl struct {
 	messageProcessor MessageProcessor
 	tagCfg           TagConfig
+	binary           string
+	logCacheDir      string
+	dir              string
+	strictTags       bool
+	onSkippedTag     func(tag string)
 }
 
-// NewGit constructor
-func NewGit(messageProcessor MessageProcessor, cfg TagConfig) *GitImpl {
+// NewGit constructor, binary is the git executable used on every command, if empty, "git" is used.
+func NewGit(messageProcessor MessageProcessor, cfg TagConfig, binary string) *GitImpl {
 	return &GitImpl{
 		messageProcessor: messageProcessor,
 		tagCfg:           cfg,
+		binary:           str(binary, "git"),
 	}
 }
 
 // LastTag get last tag, if no tag found, return empty
-func (GitImpl) LastTag() string {
-	cmd := exec.Command("git", "for-each-ref", "refs/tags", "--sort", "-creatordate", "--format", "%(refname:short)", "--count", "1")
+func (g GitImpl) LastTag() string {
+	return g.lastTag("")
+}
+
+// LastPrefixedTag get last tag starting with prefix, eg.: "sub/" for go multi-module tags, if ref is defined, only tags reachable from it are used. If no tag found, return empty
+func (g GitImpl) LastPrefixedTag(prefix, ref string) string {
+	if ref != "" {
+		return g.lastTag(prefix, "--merged", ref)
+	}
+	return g.lastTag(prefix)
+}
+
+// SetMergedOnly define if only tags reachable from HEAD are considered, overriding tag config.
+func (g *GitImpl) SetMergedOnly(mergedOnly bool) {
+	g.tagCfg.MergedOnly = mergedOnly
+}
+
+// SetLogCache define dir used to cache git log output, if empty, cache is disabled.
+func (g *GitImpl) SetLogCache(dir string) {
+	g.logCacheDir = dir
+}
+
+// SetStrictTags define if last tag lookup keeps tags that aren't valid versions, eg.: "latest", failing when they're parsed,
+// by default they're skipped and the next most recent valid tag is used.
+func (g *GitImpl) SetStrictTags(strict bool) {
+	g.strictTags = strict
+}
+
+// SetSkippedTagHandler define function called with each invalid tag skipped by last tag lookup, eg.: to warn about it.
+func (g *GitImpl) SetSkippedTagHandler(fn func(tag string)) {
+	g.onSkippedTag = fn
+}
+
+// SetDir define dir where git commands run (using git -C), if empty, current working dir is used.
+func (g *GitImpl) SetDir(dir string) {
+	g.dir = dir
+}
+
+// command create git command, running on configured dir if defined.
+func (g GitImpl) command(args ...string) *exec.Cmd {
+	if g.dir != "" {
+		args = append([]string{"-C", g.dir}, args...)
+	}
+	return exec.Command(g.binary, args...)
+}
+
+// mergedArgs args used to filter tags reachable from HEAD if merged only is enabled and no other ref is defined.
+func (g GitImpl) mergedArgs(args []string) []string {
+	if !g.tagCfg.MergedOnly || len(args) > 0 {
+		return args
+	}
+	return []string{"--merged", "HEAD"}
+}
+
+// LastTagWithPrereleases get last tag like LastPrefixedTag, but pre-release tags are always considered, eg.: to promote a pre-release.
+func (g GitImpl) LastTagWithPrereleases(prefix, ref string) string {
+	var args []string
+	if ref != "" {
+		args = []string{"--merged", ref}
+	}
+	return g.lastTagFilter(prefix, true, args...)
+}
+
+func (g GitImpl) lastTag(prefix string, args ...string) string {
+	return g.lastTagFilter(prefix, !g.tagCfg.IgnorePrereleases, args...)
+}
+
+func (g GitImpl) lastTagFilter(prefix string, includePrereleases bool, args ...string) string {
+	args = g.mergedArgs(args)
+	if g.tagCfg.UseHighest {
+		return g.highestTag(prefix, includePrereleases, args...)
+	}
+
+	params := []string{"for-each-ref", tagsRefPattern(prefix), "--sort", "-creatordate", "--format", "%(creatordate:iso8601)#%(refname:short)#%(*objecttype)%00"}
+	cmd := g.command(append(params, args...)...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return ""
 	}
-	return strings.TrimSpace(strings.Trim(string(out), "\n"))
+	gitTags, err := parseTagsOutput(string(out))
+	if err != nil || len(gitTags) == 0 {
+		return ""
+	}
+	if g.dereferenceTagDates(gitTags) {
+		sort.SliceStable(gitTags, func(i, j int) bool {
+			return gitTags[i].Date.After(gitTags[j].Date)
+		})
+	}
+
+	tags := make([]string, len(gitTags))
+	for i, tag := range gitTags {
+		tags[i] = tag.Name
+	}
+	if !includePrereleases {
+		tags = withoutPrereleases(tags, prefix)
+	}
+	if !g.strictTags {
+		tags = g.skipLeadingInvalidTags(tags, prefix)
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(tags[0])
+}
+
+// skipLeadingInvalidTags remove tags that aren't valid versions ignoring prefix, until a valid one is found, calling skipped tag handler for each removed tag.
+func (g GitImpl) skipLeadingInvalidTags(tags []string, prefix string) []string {
+	for i, tag := range tags {
+		if _, err := semver.NewVersion(strings.TrimPrefix(strings.TrimSpace(tag), prefix)); err == nil {
+			return tags[i:]
+		}
+		if g.onSkippedTag != nil {
+			g.onSkippedTag(strings.TrimSpace(tag))
+		}
+	}
+	return nil
+}
+
+func (g GitImpl) highestTag(prefix string, includePrereleases bool, args ...string) string {
+	params := append([]string{"for-each-ref", tagsRefPattern(prefix), "--format", "%(refname:short)"}, args...)
+	cmd := g.command(params...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return ""
+	}
+
+	tags := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if !includePrereleases {
+		tags = withoutPrereleases(tags, prefix)
+	}
+	return highestVersionTag(tags, prefix)
+}
+
+// withoutPrereleases remove tags with pre-release versions ignoring prefix, tags that aren't valid versions are kept.
+func withoutPrereleases(tags []string, prefix string) []string {
+	var result []string
+	for _, tag := range tags {
+		v, err := semver.NewVersion(strings.TrimPrefix(strings.TrimSpace(tag), prefix))
+		if err == nil && v.Prerelease() != "" {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// tagsRefPattern for-each-ref pattern matching tags under prefix.
+func tagsRefPattern(prefix string) string {
+	return strings.TrimSuffix("refs/tags/"+prefix, "/")
+}
+
+// highestVersionTag return tag with highest semantic version ignoring prefix, tags that aren't valid versions are ignored.
+func highestVersionTag(tags []string, prefix string) string {
+	var highest string
+	var highestVersion *semver.Version
+	for _, tag := range tags {
+		v, err := semver.NewVersion(strings.TrimPrefix(strings.TrimSpace(tag), prefix))
+		if err != nil {
+			continue
+		}
+		if highestVersion == nil || v.GreaterThan(highestVersion) {
+			highest, highestVersion = strings.TrimSpace(tag), v
+		}
+	}
+	return highest
 }
 
 // Log return git log
 func (g GitImpl) Log(lr LogRange) ([]GitCommitLog, error) {
-	format := "--pretty=format:\"%ad" + logSeparator + "%h" + logSeparator + "%s" + logSeparator + "%b" + endLine + "\""
+	format := "--pretty=format:\"%ad" + logSeparator + "%cd" + logSeparator + "%h" + logSeparator + "%an" + logSeparator + "%ae" + logSeparator + "%s" + logSeparator + "%b" + endLine + "\""
 	params := []string{"log", "--date=short", format}
 
+	var revs []string
 	if lr.start != "" || lr.end != "" {
 		switch lr.rangeType {
 		case DateRange:
@@ -100,55 +303,284 @@ func (g GitImpl) Log(lr LogRange) ([]GitCommitLog, error) {
 		default:
 			if lr.start == "" {
 				params = append(params, lr.end)
+				revs = []string{lr.end}
 			} else {
 				params = append(params, lr.start+".."+str(lr.end, "HEAD"))
+				revs = []string{lr.start, str(lr.end, "HEAD")}
 			}
 		}
 	}
 
-	cmd := exec.Command("git", params...)
+	if lr.limit > 0 {
+		params = append(params, fmt.Sprintf("--max-count=%d", lr.limit))
+	}
+
+	if len(lr.paths) > 0 {
+		params = append(append(params, "--"), lr.paths...)
+	}
+
+	cache := lr.rangeType != DateRange // relative dates, eg.: "2 weeks ago", change without any ref change
+	out, err := g.logOutput(params, revs, cache)
+	if err != nil {
+		return nil, err
+	}
+	return parseLogOutput(g.messageProcessor, out), nil
+}
+
+// logOutput run git log, if log cache is enabled and cache is true, output is reused while HEAD, revs and params are the same.
+func (g GitImpl) logOutput(params, revs []string, cache bool) (string, error) {
+	file := ""
+	if cache {
+		file = g.logCacheFile(params, revs)
+	}
+	if file != "" {
+		if content, err := ioutil.ReadFile(file); err == nil {
+			return string(content), nil
+		}
+	}
+
+	cmd := g.command(params...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", combinedOutputErr(err, out)
+	}
+	if file != "" {
+		_ = writeLogCache(g.logCacheDir, file, out) // cache is best effort, a failure only means git log runs again
+	}
+	return string(out), nil
+}
+
+var headHashRegex = regexp.MustCompile("^[0-9a-f]{40,64}$")
+
+// logCacheFile cache file for params and resolved revs, eg.: a moved tag, stored on a dir named by HEAD hash,
+// return empty if cache is disabled or HEAD or any rev can't be resolved.
+func (g GitImpl) logCacheFile(params, revs []string) string {
+	if g.logCacheDir == "" {
+		return ""
+	}
+	out, err := g.command(append([]string{"rev-parse", "HEAD"}, revs...)...).Output()
+	if err != nil {
+		return ""
+	}
+	hashes := strings.Fields(string(out))
+	if len(hashes) != len(revs)+1 {
+		return ""
+	}
+	for _, hash := range hashes {
+		if !headHashRegex.MatchString(hash) {
+			return ""
+		}
+	}
+	wd, _ := os.Getwd() // paths are relative to working dir
+	if filepath.IsAbs(g.dir) {
+		wd = g.dir
+	} else if g.dir != "" {
+		wd = filepath.Join(wd, g.dir)
+	}
+	sum := sha256.Sum256([]byte(wd + "\x00" + strings.Join(params, "\x00") + "\x00" + strings.Join(hashes[1:], "\x00")))
+	return filepath.Join(g.logCacheDir, hashes[0], hex.EncodeToString(sum[:]))
+}
+
+// writeLogCache write cache file using a temp file and rename, so concurrent readers never see partial content,
+// removing entries from previous HEAD hashes.
+func writeLogCache(dir, file string, content []byte) error {
+	current := filepath.Base(filepath.Dir(file))
+	if entries, err := ioutil.ReadDir(dir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && entry.Name() != current && headHashRegex.MatchString(entry.Name()) {
+				_ = os.RemoveAll(filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// RawCommits return non merge commits reachable from ref, but not from any exclude revision, eg.: a commit hash or --remotes=origin.
+func (g GitImpl) RawCommits(ref string, exclude ...string) ([]GitRawCommit, error) {
+	params := []string{"log", "--no-merges", "--pretty=format:%h" + logSeparator + "%B%x00", ref}
+	if len(exclude) > 0 {
+		params = append(append(params, "--not"), exclude...)
+	}
+
+	cmd := g.command(params...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, combinedOutputErr(err, out)
 	}
-	return parseLogOutput(g.messageProcessor, string(out)), nil
+	return parseRawCommits(string(out)), nil
 }
 
-// Commit runs git commit
-func (g GitImpl) Commit(header, body, footer string) error {
-	cmd := exec.Command("git", "commit", "-m", header, "-m", "", "-m", body, "-m", "", "-m", footer)
+func parseRawCommits(log string) []GitRawCommit {
+	scanner := bufio.NewScanner(strings.NewReader(log))
+	scanner.Split(splitAt([]byte(nulEndLine)))
+	var commits []GitRawCommit
+	for scanner.Scan() {
+		if text := strings.TrimSpace(scanner.Text()); text != "" {
+			content := strings.SplitN(text, logSeparator, 2)
+			if len(content) == 2 {
+				commits = append(commits, GitRawCommit{Hash: content[0], Message: content[1]})
+			}
+		}
+	}
+	return commits
+}
+
+// ErrCommitSigning returned by Commit when git fails to sign the commit, eg.: signing key not available.
+var ErrCommitSigning = errors.New("git failed to sign commit")
+
+var signingFailureRegex = regexp.MustCompile(`(?i)gpg failed to sign|error: gpg|failed to sign the data`)
+
+// Commit runs git commit, if sign is true, commit is gpg signed, otherwise signing is disabled even if configured.
+func (g GitImpl) Commit(header, body, footer string, sign bool) error {
+	signFlag := "--no-gpg-sign"
+	if sign {
+		signFlag = "-S"
+	}
+	var stderr bytes.Buffer
+	cmd := g.command("commit", signFlag, "-m", header, "-m", "", "-m", body, "-m", "", "-m", footer)
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	err := cmd.Run()
+	if err != nil && sign && isSigningFailure(stderr.String()) {
+		return ErrCommitSigning
+	}
+	return err
 }
 
-// Tag create a git tag
-func (g GitImpl) Tag(version semver.Version) error {
-	tag := fmt.Sprintf(g.tagCfg.Pattern, version.Major(), version.Minor(), version.Patch())
+// isSigningFailure check if git commit output reports a signing failure.
+func isSigningFailure(output string) bool {
+	return signingFailureRegex.MatchString(output)
+}
+
+// Tag create a git tag pointing to ref, if ref is empty, HEAD is used
+func (g GitImpl) Tag(version semver.Version, ref string) error {
+	tag := g.TagName(version)
 	tagMsg := fmt.Sprintf("Version %d.%d.%d", version.Major(), version.Minor(), version.Patch())
+	if version.Metadata() != "" {
+		tagMsg = tagMsg + "+" + version.Metadata()
+	}
+
+	params := []string{"tag", "-a", tag, "-m", tagMsg}
+	if ref != "" {
+		params = append(params, ref)
+	}
 
-	tagCommand := exec.Command("git", "tag", "-a", tag, "-m", tagMsg)
+	tagCommand := g.command(params...)
 	if err := tagCommand.Run(); err != nil {
 		return err
 	}
 
-	pushCommand := exec.Command("git", "push", "origin", tag)
+	pushCommand := g.command("push", "origin", tag)
 	return pushCommand.Run()
 }
 
-// Tags list repository tags
+// TagName tag name created by Tag for version, using tag pattern.
+func (g GitImpl) TagName(version semver.Version) string {
+	tag := fmt.Sprintf(g.tagCfg.Pattern, version.Major(), version.Minor(), version.Patch())
+	if version.Metadata() != "" { // build metadata is ignored on precedence, but kept on tag name
+		tag = tag + "+" + version.Metadata()
+	}
+	return tag
+}
+
+// DeleteTag delete a git tag, if push is true, tag is also removed from remote
+func (g GitImpl) DeleteTag(tag string, push bool) error {
+	cmd := g.command("tag", "-d", tag)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return combinedOutputErr(err, out)
+	}
+
+	if !push {
+		return nil
+	}
+
+	pushCommand := g.command("push", "--delete", "origin", tag)
+	if out, err := pushCommand.CombinedOutput(); err != nil {
+		return combinedOutputErr(err, out)
+	}
+	return nil
+}
+
+// Tags list repository tags, if merged only is enabled, only tags reachable from HEAD are listed
 func (g GitImpl) Tags() ([]GitTag, error) {
-	cmd := exec.Command("git", "for-each-ref", "--sort", "creatordate", "--format", "%(creatordate:iso8601)#%(refname:short)", "refs/tags")
+	params := append([]string{"for-each-ref", "--sort", "creatordate", "--format", "%(creatordate:iso8601)#%(refname:short)#%(*objecttype)#%(objecttype)#%(contents:subject)%0a%0a%(contents:body)%00", "refs/tags"}, g.mergedArgs(nil)...)
+	cmd := g.command(params...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, combinedOutputErr(err, out)
 	}
-	return parseTagsOutput(string(out))
+	tags, err := parseTagsOutput(string(out))
+	if err != nil {
+		return nil, err
+	}
+	if g.dereferenceTagDates(tags) {
+		sort.SliceStable(tags, func(i, j int) bool {
+			return tags[i].Date.Before(tags[j].Date)
+		})
+	}
+	return tags, nil
+}
+
+// dereferenceTagDates replace date of tags pointing to other tags (tag-of-tag) by their target commit date, returns true if any date was replaced.
+func (g GitImpl) dereferenceTagDates(tags []GitTag) bool {
+	replaced := false
+	for i, tag := range tags {
+		if !tag.tagOfTag {
+			continue
+		}
+		cmd := g.command("log", "-1", "--format=%ci", tag.Name+"^{commit}")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			continue
+		}
+		if date, err := time.Parse("2006-01-02 15:04:05 -0700", strings.TrimSpace(string(out))); err == nil {
+			tags[i].Date = date
+			replaced = true
+		}
+	}
+	return replaced
+}
+
+// FetchTags fetch tags from remote
+func (g GitImpl) FetchTags(remote string) error {
+	cmd := g.command("fetch", "--tags", remote)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return combinedOutputErr(err, out)
+	}
+	return nil
+}
+
+// ConfigBool get a boolean git config value, false if it's not defined or invalid.
+func (g GitImpl) ConfigBool(key string) bool {
+	cmd := g.command("config", "--bool", key)
+	out, err := cmd.CombinedOutput()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// HasCommit check if hash is a commit available on local repository, eg.: remote hash after a force push may not be.
+func (g GitImpl) HasCommit(hash string) bool {
+	return g.command("cat-file", "-e", hash+"^{commit}").Run() == nil
 }
 
 // Branch get git branch
-func (GitImpl) Branch() string {
-	cmd := exec.Command("git", "symbolic-ref", "--short", "HEAD")
+func (g GitImpl) Branch() string {
+	cmd := g.command("symbolic-ref", "--short", "HEAD")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return ""
@@ -157,8 +589,8 @@ func (GitImpl) Branch() string {
 }
 
 // IsDetached check if is detached.
-func (GitImpl) IsDetached() (bool, error) {
-	cmd := exec.Command("git", "symbolic-ref", "-q", "HEAD")
+func (g GitImpl) IsDetached() (bool, error) {
+	cmd := g.command("symbolic-ref", "-q", "HEAD")
 	out, err := cmd.CombinedOutput()
 	if output := string(out); err != nil { //-q: do not issue an error message if the <name> is not a symbolic ref, but a detached HEAD; instead exit with non-zero status silently.
 		if output == "" {
@@ -169,14 +601,33 @@ func (GitImpl) IsDetached() (bool, error) {
 	return false, nil
 }
 
+// IsDirty check if working tree has uncommitted changes, untracked files are ignored.
+func (g GitImpl) IsDirty() (bool, error) {
+	cmd := g.command("status", "--porcelain", "--untracked-files=no")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, combinedOutputErr(err, out)
+	}
+	return strings.TrimSpace(string(out)) != "", nil
+}
+
+// parseTagsOutput parse "date#name#target type#type#contents" records, only annotated tags have a message, lightweight tags contents are from commit.
 func parseTagsOutput(input string) ([]GitTag, error) {
 	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(splitAt([]byte(nulEndLine)))
 	var result []GitTag
 	for scanner.Scan() {
 		if line := strings.TrimSpace(scanner.Text()); line != "" {
-			values := strings.Split(line, "#")
+			values := strings.SplitN(line, "#", 5)
+			if len(values) < 2 {
+				continue
+			}
 			date, _ := time.Parse("2006-01-02 15:04:05 -0700", values[0]) // ignore invalid dates
-			result = append(result, GitTag{Name: values[1], Date: date})
+			tag := GitTag{Name: values[1], Date: date, tagOfTag: len(values) >= 3 && values[2] == "tag"}
+			if len(values) == 5 && values[3] == "tag" {
+				tag.Message = strings.TrimSpace(values[4])
+			}
+			result = append(result, tag)
 		}
 	}
 	return result, nil
@@ -198,9 +649,12 @@ func parseCommitLog(messageProcessor MessageProcessor, commit string) GitCommitL
 	content := strings.Split(strings.Trim(commit, "\""), logSeparator)
 
 	return GitCommitLog{
-		Date:    content[0],
-		Hash:    content[1],
-		Message: messageProcessor.Parse(content[2], content[3]),
+		Date:          content[0],
+		CommitterDate: content[1],
+		Hash:          content[2],
+		AuthorName:    content[3],
+		AuthorEmail:   content[4],
+		Message:       messageProcessor.Parse(content[5], content[6]),
 	}
 }
 
@@ -1,9 +1,16 @@
 package sv
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/Masterminds/semver/v3"
 )
 
 func Test_parseTagsOutput(t *testing.T) {
@@ -15,6 +22,13 @@ func Test_parseTagsOutput(t *testing.T) {
 	}{
 		{"with date", "2020-05-01 18:00:00 -0300#1.0.0", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300")}}, false},
 		{"without date", "#1.0.0", []GitTag{{Name: "1.0.0", Date: time.Time{}}}, false},
+		{"annotated tag", "2020-05-01 18:00:00 -0300#1.0.0#commit#tag#Version 1.0.0\n\nsome # notes\n\x00", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300"), Message: "Version 1.0.0\n\nsome # notes"}}, false},
+		{"lightweight tag", "2020-05-01 18:00:00 -0300#1.0.0##commit#feat: something\n\n\x00", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300")}}, false},
+		{"multiple tags", "2020-05-01 18:00:00 -0300#1.0.0#commit#tag#Version 1.0.0\n\n\x00\n2020-05-02 18:00:00 -0300#1.1.0#commit#tag#Version 1.1.0\n\n\x00\n", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300"), Message: "Version 1.0.0"}, {Name: "1.1.0", Date: date("2020-05-02 18:00:00 -0300"), Message: "Version 1.1.0"}}, false},
+		{"tag message with tildes", "2020-05-01 18:00:00 -0300#1.0.0#commit#tag#Version 1.0.0\n\nuse ~~strike~~ and ~~\x00", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300"), Message: "Version 1.0.0\n\nuse ~~strike~~ and ~~"}}, false},
+		{"tag of tag", "2020-05-01 18:00:00 -0300#legacy#tag#tag#Legacy\x00", []GitTag{{Name: "legacy", Date: date("2020-05-01 18:00:00 -0300"), Message: "Legacy", tagOfTag: true}}, false},
+		{"last tag format", "2020-05-01 18:00:00 -0300#1.0.0#commit\x002020-05-01 18:00:00 -0300#legacy#tag\x00", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300")}, {Name: "legacy", Date: date("2020-05-01 18:00:00 -0300"), tagOfTag: true}}, false},
+		{"skip malformed record", "garbage\x002020-05-01 18:00:00 -0300#1.0.0\x00", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300")}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -37,3 +51,275 @@ func date(input string) time.Time {
 	}
 	return t
 }
+
+func Test_highestVersionTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   []string
+		prefix string
+		want   string
+	}{
+		{"empty", []string{""}, "", ""},
+		{"single tag", []string{"1.0.0"}, "", "1.0.0"},
+		{"highest not last", []string{"1.2.0", "2.0.0", "1.10.0"}, "", "2.0.0"},
+		{"semantic comparison", []string{"v1.9.0", "v1.10.0", "v1.2.0"}, "", "v1.10.0"},
+		{"ignore invalid versions", []string{"1.0.0", "nightly", "latest"}, "", "1.0.0"},
+		{"prefixed tags", []string{"sub/v1.2.0", "sub/v1.10.0"}, "sub/", "sub/v1.10.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highestVersionTag(tt.tags, tt.prefix); got != tt.want {
+				t.Errorf("highestVersionTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseRawCommits(t *testing.T) {
+	tests := []struct {
+		name string
+		log  string
+		want []GitRawCommit
+	}{
+		{"empty", "", nil},
+		{"single line message", "a1b2c3d##feat: something\n\x00", []GitRawCommit{{"a1b2c3d", "feat: something"}}},
+		{"multiple commits", "a1b2c3d##feat: something\n\nbody\n\njira: JIRA-123\n\x00\ne4f5a6b##fix: other\n\x00", []GitRawCommit{{"a1b2c3d", "feat: something\n\nbody\n\njira: JIRA-123"}, {"e4f5a6b", "fix: other"}}},
+		{"body with tildes", "a1b2c3d##feat: something\n\nuse ~~old~~ api\n\x00", []GitRawCommit{{"a1b2c3d", "feat: something\n\nuse ~~old~~ api"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRawCommits(tt.log); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRawCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseLogOutput(t *testing.T) {
+	p := NewMessageProcessor(ccfg, newBranchCfg(false))
+	log := "\"2020-05-01##2020-06-01##a1b2c3d##author##author@example.com##feat: something##~~\"\n\"2020-04-01##2020-04-01##e4f5a6b##other##other@example.com##fix: other##body~~\""
+	want := []GitCommitLog{
+		{Date: "2020-05-01", CommitterDate: "2020-06-01", Hash: "a1b2c3d", AuthorName: "author", AuthorEmail: "author@example.com", Message: p.Parse("feat: something", "")},
+		{Date: "2020-04-01", CommitterDate: "2020-04-01", Hash: "e4f5a6b", AuthorName: "other", AuthorEmail: "other@example.com", Message: p.Parse("fix: other", "body")},
+	}
+	if got := parseLogOutput(p, log); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLogOutput() = %v, want %v", got, want)
+	}
+}
+
+func TestGitImpl_mergedArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		mergedOnly bool
+		args       []string
+		want       []string
+	}{
+		{"disabled", false, nil, nil},
+		{"enabled", true, nil, []string{"--merged", "HEAD"}},
+		{"enabled with ref", true, []string{"--merged", "main"}, []string{"--merged", "main"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGit(nil, TagConfig{}, "")
+			g.SetMergedOnly(tt.mergedOnly)
+			if got := g.mergedArgs(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GitImpl.mergedArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitImpl_TagName(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		version string
+		want    string
+	}{
+		{"default pattern", "%d.%d.%d", "1.2.3", "1.2.3"},
+		{"prefixed pattern", "v%d.%d.%d", "1.2.3", "v1.2.3"},
+		{"custom pattern", "release-%d.%d.%d", "1.2.3", "release-1.2.3"},
+		{"with metadata", "v%d.%d.%d", "1.2.3+build.42", "v1.2.3+build.42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGit(nil, TagConfig{Pattern: tt.pattern}, "")
+			if got := g.TagName(*semver.MustParse(tt.version)); got != tt.want {
+				t.Errorf("GitImpl.TagName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_withoutPrereleases(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   []string
+		prefix string
+		want   []string
+	}{
+		{"no pre-releases", []string{"1.2.0", "1.1.0"}, "", []string{"1.2.0", "1.1.0"}},
+		{"pre-releases", []string{"1.2.0-rc.1", "v1.1.0", "1.1.0-beta"}, "", []string{"v1.1.0"}},
+		{"keep invalid versions", []string{"nightly", "1.2.0-rc.1"}, "", []string{"nightly"}},
+		{"prefixed tags", []string{"sub/v1.2.0-rc.1", "sub/v1.1.0"}, "sub/", []string{"sub/v1.1.0"}},
+		{"only pre-releases", []string{"1.0.0-rc.1"}, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withoutPrereleases(tt.tags, tt.prefix); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withoutPrereleases() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitImpl_skipLeadingInvalidTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []string
+		prefix      string
+		want        []string
+		wantSkipped []string
+	}{
+		{"valid last tag", []string{"1.2.0", "latest"}, "", []string{"1.2.0", "latest"}, nil},
+		{"invalid last tags", []string{"latest", "nightly", "v1.1.0", "1.0.0"}, "", []string{"v1.1.0", "1.0.0"}, []string{"latest", "nightly"}},
+		{"prefixed tags", []string{"sub/latest", "sub/1.1.0"}, "sub/", []string{"sub/1.1.0"}, []string{"sub/latest"}},
+		{"only invalid tags", []string{"latest"}, "", nil, []string{"latest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var skipped []string
+			g := NewGit(nil, TagConfig{}, "")
+			g.SetSkippedTagHandler(func(tag string) { skipped = append(skipped, tag) })
+			if got := g.skipLeadingInvalidTags(tt.tags, tt.prefix); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GitImpl.skipLeadingInvalidTags() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(skipped, tt.wantSkipped) {
+				t.Errorf("GitImpl.skipLeadingInvalidTags() skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func Test_isSigningFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"gpg failure", "error: gpg failed to sign the data\nfatal: failed to write commit object\n", true},
+		{"gpg program error", "error: gpg: signing failed: No secret key\n", true},
+		{"hook rejection", "commit-msg hook rejected message\n", false},
+		{"nothing to commit", "nothing to commit, working tree clean\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSigningFailure(tt.output); got != tt.want {
+				t.Errorf("isSigningFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testRepo create a git repository on a temp dir, returns git pointing to it and a function to run git commands on it,
+// each command runs one day after the previous one, starting on 2020-01-01.
+func testRepo(t *testing.T) (*GitImpl, func(args ...string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	day := 0
+	run := func(args ...string) {
+		t.Helper()
+		day++
+		args = append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)
+		cmd := exec.Command("git", args...)
+		date := fmt.Sprintf("2020-01-%02dT12:00:00+0000", day)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v, %s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	g := NewGit(NewMessageProcessor(ccfg, newBranchCfg(false)), TagConfig{Pattern: "%d.%d.%d"}, "git")
+	g.SetDir(dir)
+	return g, run
+}
+
+func TestGitImpl_logOutput_cache(t *testing.T) {
+	g, run := testRepo(t)
+	g.SetLogCache(t.TempDir())
+	run("commit", "-q", "--allow-empty", "-m", "feat: first")
+	run("tag", "1.0.0")
+	run("commit", "-q", "--allow-empty", "-m", "feat: second")
+	params, revs := []string{"log", "--format=%s", "1.0.0..HEAD"}, []string{"1.0.0", "HEAD"}
+
+	file := g.logCacheFile(params, revs)
+	if file == "" {
+		t.Fatal("logCacheFile() is empty, want cache file")
+	}
+	if got, err := g.logOutput(params, revs, true); err != nil || got != "feat: second\n" {
+		t.Fatalf("logOutput() miss = %q, %v, want %q", got, err, "feat: second\n")
+	}
+	if err := ioutil.WriteFile(file, []byte("cached"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := g.logOutput(params, revs, true); got != "cached" {
+		t.Errorf("logOutput() hit = %q, want %q", got, "cached")
+	}
+	if got, _ := g.logOutput(params, revs, false); got != "feat: second\n" {
+		t.Errorf("logOutput() without cache = %q, want %q", got, "feat: second\n")
+	}
+	if tmp, _ := filepath.Glob(filepath.Join(filepath.Dir(file), ".tmp-*")); len(tmp) > 0 {
+		t.Errorf("writeLogCache() left temp files: %v", tmp)
+	}
+
+	run("tag", "-f", "1.0.0", "HEAD")
+	if moved := g.logCacheFile(params, revs); moved == file {
+		t.Errorf("logCacheFile() = %s after moving tag, want a different file", moved)
+	}
+	run("tag", "-f", "1.0.0", "HEAD~1")
+
+	run("commit", "-q", "--allow-empty", "-m", "fix: third")
+	newFile := g.logCacheFile(params, revs)
+	if newFile == file || filepath.Dir(newFile) == filepath.Dir(file) {
+		t.Fatalf("logCacheFile() = %s after HEAD change, want a different HEAD dir than %s", newFile, file)
+	}
+	if got, _ := g.logOutput(params, revs, true); got != "fix: third\nfeat: second\n" {
+		t.Errorf("logOutput() after HEAD change = %q, want %q", got, "fix: third\nfeat: second\n")
+	}
+	if _, err := ioutil.ReadFile(file); err == nil {
+		t.Errorf("cache file from previous HEAD %s was not removed", file)
+	}
+}
+
+func TestGitImpl_Tags_tagOfTag(t *testing.T) {
+	g, run := testRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "feat: first")
+	run("tag", "-a", "1.0.0", "-m", "Version 1.0.0")
+	run("commit", "-q", "--allow-empty", "-m", "feat: second")
+	run("tag", "-a", "1.1.0", "-m", "Version 1.1.0")
+	run("tag", "-a", "legacy", "1.0.0", "-m", "Legacy name for 1.0.0")
+
+	tags, err := g.Tags()
+	if err != nil {
+		t.Fatalf("Tags() error = %v", err)
+	}
+	want := []GitTag{
+		{Name: "legacy", Date: date("2020-01-02 12:00:00 +0000"), Message: "Legacy name for 1.0.0", tagOfTag: true},
+		{Name: "1.0.0", Date: date("2020-01-03 12:00:00 +0000"), Message: "Version 1.0.0"},
+		{Name: "1.1.0", Date: date("2020-01-05 12:00:00 +0000"), Message: "Version 1.1.0"},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("Tags() = %v, want %v", tags, want)
+	}
+	for i := range want {
+		if tags[i].Name != want[i].Name || !tags[i].Date.Equal(want[i].Date) || tags[i].Message != want[i].Message || tags[i].tagOfTag != want[i].tagOfTag {
+			t.Errorf("Tags()[%d] = %v, want %v", i, tags[i], want[i])
+		}
+	}
+	if got := g.LastTag(); got != "1.1.0" {
+		t.Errorf("LastTag() = %v, want %v", got, "1.1.0")
+	}
+}
@@ -2,17 +2,35 @@ package sv
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
-	breakingChangeFooterKey   = "BREAKING CHANGE"
-	breakingChangeMetadataKey = "breaking-change"
-	issueMetadataKey          = "issue"
+	breakingChangeFooterKey    = "BREAKING CHANGE"
+	breakingChangeFooterAltKey = "BREAKING-CHANGE"
+	breakingChangeMetadataKey  = "breaking-change"
+	issueMetadataKey           = "issue"
+	headerPattern              = "^[a-z+]+(\\(.+\\))?!?: .+$"
+	emptySubjectPattern        = "^[a-z+]+(\\(.+\\))?!?:\\s*$"
+	mergeRequestPrefix         = "!"
+	scissorsLine               = "# ------------------------ >8 ------------------------"
 )
 
+var fixupRegex = regexp.MustCompile(`^(fixup|squash|amend)! `)
+
+// pullRequestSuffixRegex pull request number appended to subject by GitHub squash merges, eg.: "feat: add button (#123)".
+var pullRequestSuffixRegex = regexp.MustCompile(`\s\(#([0-9]+)\)$`)
+
+var footerRegex = regexp.MustCompile(`^(` + breakingChangeFooterKey + `|[\w-]+)(?:: (.*)| ([#!].*))$`)
+
+var paragraphSeparatorRegex = regexp.MustCompile(`\n\s*\n`)
+
 // CommitMessage is a message using conventional commits.
 type CommitMessage struct {
 	Type             string            `json:"type,omitempty"`
@@ -20,9 +38,16 @@ type CommitMessage struct {
 	Description      string            `json:"description,omitempty"`
 	Body             string            `json:"body,omitempty"`
 	IsBreakingChange bool              `json:"isBreakingChange,omitempty"`
+	PullRequest      string            `json:"pullRequest,omitempty"`
 	Metadata         map[string]string `json:"metadata,omitempty"`
 }
 
+// CommitMessageFooter footer token and value.
+type CommitMessageFooter struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 // NewCommitMessage commit message constructor
 func NewCommitMessage(ctype, scope, description, body, issue, breakingChanges string) CommitMessage {
 	metadata := make(map[string]string)
@@ -45,11 +70,31 @@ func (m CommitMessage) BreakingMessage() string {
 	return m.Metadata[breakingChangeMetadataKey]
 }
 
+// Footers return footers from last body paragraph, using "Key: value" or "Key #value" format.
+// Lines that aren't footers are appended to previous footer value.
+func (m CommitMessage) Footers() []CommitMessageFooter {
+	footers, _, _ := parseFooterLines(footerLines(m.Body))
+	return footers
+}
+
+// ParseCommitMessage parse a raw commit message, returns an error if header is not a valid conventional commit.
+// Only spec-level breaking change footers are extracted as metadata, other footers, eg.: issues, are available with Footers,
+// use MessageProcessor to parse with a custom footer config.
+func ParseCommitMessage(raw string) (CommitMessage, error) {
+	subject, body := splitCommitMessageContent(raw)
+	if !regexp.MustCompile(headerPattern).MatchString(subject) {
+		return CommitMessage{}, fmt.Errorf("subject [%s] should be valid according with conventional commits", subject)
+	}
+	return NewMessageProcessor(CommitMessageConfig{}, BranchesConfig{}).Parse(subject, strings.TrimSpace(body)), nil
+}
+
 // MessageProcessor interface.
 type MessageProcessor interface {
 	SkipBranch(branch string, detached bool) bool
 	Validate(message string) error
 	Enhance(branch string, message string) (string, error)
+	ValidationProblems(message string) []ValidationProblem
+	HeaderProblems(message string) []ValidationProblem
 	IssueID(branch string) (string, error)
 	Format(msg CommitMessage) (string, string, string)
 	Parse(subject, body string) CommitMessage
@@ -57,70 +102,302 @@ type MessageProcessor interface {
 
 // NewMessageProcessor MessageProcessorImpl constructor
 func NewMessageProcessor(mcfg CommitMessageConfig, bcfg BranchesConfig) *MessageProcessorImpl {
+	regexes, err := subjectIssueRegexes(mcfg.Issue.Regex, mcfg.IssueFooterConfig().AddValuePrefix)
 	return &MessageProcessorImpl{
-		messageCfg:  mcfg,
-		branchesCfg: bcfg,
+		messageCfg:          mcfg,
+		branchesCfg:         bcfg,
+		subjectIssueRegexes: regexes,
+		subjectIssueErr:     err,
 	}
 }
 
 // MessageProcessorImpl process validate message hook.
 type MessageProcessorImpl struct {
-	messageCfg  CommitMessageConfig
-	branchesCfg BranchesConfig
+	messageCfg          CommitMessageConfig
+	branchesCfg         BranchesConfig
+	subjectIssueRegexes []*regexp.Regexp
+	subjectIssueErr     error
 }
 
 // SkipBranch check if branch should be ignored.
 func (p MessageProcessorImpl) SkipBranch(branch string, detached bool) bool {
-	return contains(branch, p.branchesCfg.Skip) || (p.branchesCfg.SkipDetached != nil && *p.branchesCfg.SkipDetached && detached)
+	return contains(branch, p.branchesCfg.Skip) || matchesAny(branch, p.branchesCfg.SkipRegex) || (p.branchesCfg.SkipDetached != nil && *p.branchesCfg.SkipDetached && detached)
 }
 
-// Validate commit message.
+// validation rules used on ValidationProblem.
+const (
+	RuleHeaderFormat        = "header-format"
+	RuleSubjectEmpty        = "subject-empty"
+	RuleFixup               = "fixup"
+	RuleTypeDenied          = "type-denied"
+	RuleTypeEnum            = "type-enum"
+	RuleScopeRequired       = "scope-required"
+	RuleScopeEnum           = "scope-enum"
+	RuleFooterFormat        = "footer-format"
+	RuleFooterToken         = "footer-token"
+	RuleBreakingChangeEmpty = "breaking-change-empty"
+	RuleSubjectCase         = "subject-case"
+	RuleSubjectFullStop     = "subject-full-stop"
+	RuleBodyMaxLineLength   = "body-max-line-length"
+)
+
+// ValidationProblem commit message validation problem, line and column start at 1.
+type ValidationProblem struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// Validate commit message, returns an error with every validation problem found.
 func (p MessageProcessorImpl) Validate(message string) error {
+	problems := p.ValidationProblems(message)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.Message
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// ValidationProblems validate commit message, returns every problem found, if header is invalid, only header problem is returned.
+func (p MessageProcessorImpl) ValidationProblems(message string) []ValidationProblem {
 	subject, body := splitCommitMessageContent(message)
+	if prefix := fixupRegex.FindString(subject); prefix != "" && p.messageCfg.AllowFixup {
+		return p.ValidationProblems(strings.TrimPrefix(message, prefix))
+	}
+
+	problems, valid := p.headerProblems(subject)
+	if !valid {
+		return problems
+	}
 	msg := p.Parse(subject, body)
 
-	if !regexp.MustCompile("^[a-z+]+(\\(.+\\))?!?: .+$").MatchString(subject) {
-		return fmt.Errorf("subject [%s] should be valid according with conventional commits", subject)
+	if p.messageCfg.FooterValidation.Enabled {
+		problems = append(problems, p.footerProblems(msg.Body)...)
 	}
+	problems = append(problems, p.breakingChangeProblems(body)...)
 
-	if msg.Type == "" || !contains(msg.Type, p.messageCfg.Types) {
-		return fmt.Errorf("message type should be one of [%v]", strings.Join(p.messageCfg.Types, ", "))
+	if p.messageCfg.Body.MaxLineLength > 0 {
+		for _, line := range bodyLongLines(body, p.messageCfg.Body.MaxLineLength) {
+			problems = append(problems, ValidationProblem{RuleBodyMaxLineLength, fmt.Sprintf("body line %d should not exceed %d characters", line, p.messageCfg.Body.MaxLineLength), line, p.messageCfg.Body.MaxLineLength + 1})
+		}
 	}
 
-	if len(p.messageCfg.Scope.Values) > 0 && !contains(msg.Scope, p.messageCfg.Scope.Values) {
-		return fmt.Errorf("message scope should one of [%v]", strings.Join(p.messageCfg.Scope.Values, ", "))
+	return problems
+}
+
+// HeaderProblems validate only commit message header, skipping body and footer rules, eg.: for interactive validation.
+func (p MessageProcessorImpl) HeaderProblems(message string) []ValidationProblem {
+	subject, _ := splitCommitMessageContent(message)
+	if prefix := fixupRegex.FindString(subject); prefix != "" && p.messageCfg.AllowFixup {
+		subject = strings.TrimPrefix(subject, prefix)
 	}
+	problems, _ := p.headerProblems(subject)
+	return problems
+}
 
-	return nil
+// headerProblems validate header rules, returns false if header format is invalid and other rules were not checked.
+func (p MessageProcessorImpl) headerProblems(subject string) ([]ValidationProblem, bool) {
+	if prefix := fixupRegex.FindString(subject); prefix != "" {
+		return []ValidationProblem{{RuleFixup, fmt.Sprintf("%s commits should be squashed before merging, subject: [%s]", strings.TrimSpace(prefix), subject), 1, 1}}, false
+	}
+	if regexp.MustCompile(emptySubjectPattern).MatchString(subject) {
+		return []ValidationProblem{{RuleSubjectEmpty, p.withHeaderHint(fmt.Sprintf("message description should not be empty, subject: [%s]", subject)), 1, utf8.RuneCountInString(subject) + 1}}, false
+	}
+	if !regexp.MustCompile(headerPattern).MatchString(subject) {
+		return []ValidationProblem{{RuleHeaderFormat, p.withHeaderHint(fmt.Sprintf("subject [%s] should be valid according with conventional commits", subject)), 1, 1}}, false
+	}
+	msg := p.Parse(subject, "")
+
+	var problems []ValidationProblem
+	if contains(msg.Type, p.messageCfg.DeniedTypes) {
+		problems = append(problems, ValidationProblem{RuleTypeDenied, fmt.Sprintf("message type [%s] is denied by policy, denied types: [%v]", msg.Type, strings.Join(p.messageCfg.DeniedTypes, ", ")), 1, 1})
+	} else if msg.Type == "" || !contains(msg.Type, p.messageCfg.Types) {
+		problems = append(problems, ValidationProblem{RuleTypeEnum, fmt.Sprintf("message type should be one of [%v]", strings.Join(p.messageCfg.Types, ", ")), 1, 1})
+	}
+
+	scopeColumn := utf8.RuneCountInString(msg.Type) + 1
+	if p.messageCfg.Scope.Required && msg.Scope == "" {
+		problems = append(problems, ValidationProblem{RuleScopeRequired, "message scope is required", 1, scopeColumn})
+	} else if len(p.messageCfg.Scope.Values) > 0 && !regexp.MustCompile(ScopeRegex(p.messageCfg.Scope.Values)).MatchString(msg.Scope) {
+		problems = append(problems, ValidationProblem{RuleScopeEnum, fmt.Sprintf("message scope should one of [%v]", strings.Join(p.messageCfg.Scope.Values, ", ")), 1, scopeColumn})
+	}
+
+	descriptionColumn := utf8.RuneCountInString(subject[:strings.Index(subject, ": ")]) + 3
+	if p.messageCfg.Subject.Lowercase && startsWithUpper(msg.Description) {
+		problems = append(problems, ValidationProblem{RuleSubjectCase, fmt.Sprintf("message description [%s] should not start with an uppercase letter", msg.Description), 1, descriptionColumn})
+	}
+
+	if p.messageCfg.Subject.NoTrailingPeriod && strings.HasSuffix(msg.Description, ".") {
+		problems = append(problems, ValidationProblem{RuleSubjectFullStop, fmt.Sprintf("message description [%s] should not end with a period", msg.Description), 1, descriptionColumn + utf8.RuneCountInString(msg.Description) - 1})
+	}
+	return problems, true
+}
+
+// withHeaderHint append configured header hint to message, eg.: an example of a valid header.
+func (p MessageProcessorImpl) withHeaderHint(message string) string {
+	if p.messageCfg.HeaderHint == "" {
+		return message
+	}
+	return message + ", " + p.messageCfg.HeaderHint
 }
 
-// Enhance add metadata on commit message.
+// Enhance add metadata on commit message, returns content that should be appended on message footer,
+// or at the end of subject if issue placement is subject-suffix.
 func (p MessageProcessorImpl) Enhance(branch string, message string) (string, error) {
-	if p.branchesCfg.DisableIssue || p.messageCfg.IssueFooterConfig().Key == "" || hasIssueID(message, p.messageCfg.IssueFooterConfig()) {
-		return "", nil //enhance disabled
+	if p.messageCfg.Issue.IsSubjectSuffix() {
+		if p.subjectIssueErr != nil {
+			return "", p.subjectIssueErr
+		}
+		subject, _ := splitCommitMessageContent(message)
+		if p.branchesCfg.DisableIssue || subjectIssue(subject, p.subjectIssueRegexes) != "" {
+			return "", nil //enhance disabled
+		}
+		issue, footerCfg, err := p.branchIssue(branch)
+		if err != nil {
+			return "", err
+		}
+		return " " + formatIssueSuffix(footerCfg, issue), nil
 	}
 
-	issue, err := p.IssueID(branch)
+	if p.branchesCfg.DisableIssue {
+		return "", nil //enhance disabled
+	}
+	issue, footerCfg, found, err := p.trackerIssue(branch)
 	if err != nil {
 		return "", err
 	}
-	if issue == "" {
-		return "", fmt.Errorf("could not find issue id using configured regex")
+	if !found {
+		footerCfg = p.messageCfg.IssueFooterConfig()
+	}
+	if footerCfg.Key == "" || hasIssueID(message, footerCfg) {
+		return "", nil //enhance disabled
+	}
+	if !found {
+		if issue, _, err = p.branchIssue(branch); err != nil {
+			return "", err
+		}
 	}
 
-	footer := formatIssueFooter(p.messageCfg.IssueFooterConfig(), issue)
-	if !hasFooter(message) {
+	footer := formatIssueFooter(footerCfg, issue)
+	if !hasFooter(message, footerKeys(p.messageCfg.BreakingChangeFooterConfig())...) {
 		return "\n" + footer, nil
 	}
 
 	return footer, nil
 }
 
+// branchIssue get issue and footer config from branch, tracker footer is used if branch prefix matches a tracker.
+func (p MessageProcessorImpl) branchIssue(branch string) (string, CommitMessageFooterConfig, error) {
+	issue, footerCfg, found, err := p.trackerIssue(branch)
+	if err != nil {
+		return "", footerCfg, err
+	}
+	if found {
+		return issue, footerCfg, nil
+	}
+
+	issue, err = p.IssueID(branch)
+	if err != nil {
+		return "", footerCfg, err
+	}
+	if issue == "" {
+		return "", footerCfg, fmt.Errorf("could not find issue id using configured regex")
+	}
+	return issue, p.messageCfg.IssueFooterConfig(), nil
+}
+
+// trackerIssue try to extract issue from branch using tracker config matching branch prefix, eg.: "jira/PROJ-1" or "gh/42".
+func (p MessageProcessorImpl) trackerIssue(branch string) (string, CommitMessageFooterConfig, bool, error) {
+	for _, name := range sortedTrackers(p.branchesCfg.Trackers) {
+		tracker := p.branchesCfg.Trackers[name]
+		if !strings.HasPrefix(branch, name+"/") {
+			continue
+		}
+		regexes := tracker.Regex
+		if len(regexes) == 0 {
+			regexes = p.messageCfg.Issue.Regex
+		}
+		for _, issueRegex := range regexes {
+			rstr := fmt.Sprintf("^%s/(%s)%s$", regexp.QuoteMeta(name), issueRegex, p.branchesCfg.SuffixRegex)
+			r, err := regexp.Compile(rstr)
+			if err != nil {
+				return "", CommitMessageFooterConfig{}, false, fmt.Errorf("could not compile issue regex: %s, error: %v", rstr, err.Error())
+			}
+			if groups := r.FindStringSubmatch(branch); len(groups) >= 2 {
+				return groups[1], tracker.Footer, true, nil
+			}
+		}
+	}
+	return "", CommitMessageFooterConfig{}, false, nil
+}
+
+// sortedTrackers tracker names, longest first, so "gh-enterprise" is tried before "gh".
+func sortedTrackers(trackers map[string]BranchTrackerConfig) []string {
+	names := make([]string, 0, len(trackers))
+	for name := range trackers {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func formatIssueSuffix(cfg CommitMessageFooterConfig, issue string) string {
+	if !strings.HasPrefix(issue, cfg.AddValuePrefix) {
+		issue = cfg.AddValuePrefix + issue
+	}
+	return "(" + issue + ")"
+}
+
+// subjectIssueRegexes compile regexes matching issue at the end of subject, eg.: "feat: something (#123)", if empty, any value between parentheses is matched.
+// Invalid regexes are skipped, returning the first compilation error.
+func subjectIssueRegexes(issueRegexes []string, prefix string) ([]*regexp.Regexp, error) {
+	if len(issueRegexes) == 0 {
+		issueRegexes = []string{"[^()]+"}
+	}
+	var result []*regexp.Regexp
+	var firstErr error
+	for _, value := range issueRegexes {
+		rstr := `\s\(((?:` + regexp.QuoteMeta(prefix) + `)?(?:` + value + `))\)$`
+		r, err := regexp.Compile(rstr)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("could not compile issue regex: %s, error: %v", rstr, err.Error())
+			}
+			continue
+		}
+		result = append(result, r)
+	}
+	return result, firstErr
+}
+
+// subjectIssue extract issue from the end of subject, regexes are tried in order.
+func subjectIssue(subject string, regexes []*regexp.Regexp) string {
+	for _, r := range regexes {
+		if result := r.FindStringSubmatch(strings.TrimSpace(subject)); len(result) >= 2 {
+			return result[1]
+		}
+	}
+	return ""
+}
+
 func formatIssueFooter(cfg CommitMessageFooterConfig, issue string) string {
 	if !strings.HasPrefix(issue, cfg.AddValuePrefix) {
 		issue = cfg.AddValuePrefix + issue
 	}
-	if cfg.UseHash {
+	if cfg.HasHashSeparator() {
+		if strings.HasPrefix(issue, mergeRequestPrefix) {
+			return fmt.Sprintf("%s %s", cfg.Key, issue)
+		}
 		return fmt.Sprintf("%s #%s", cfg.Key, strings.TrimPrefix(issue, "#"))
 	}
 	return fmt.Sprintf("%s: %s", cfg.Key, issue)
@@ -128,21 +405,25 @@ func formatIssueFooter(cfg CommitMessageFooterConfig, issue string) string {
 
 // IssueID try to extract issue id from branch, return empty if not found.
 func (p MessageProcessorImpl) IssueID(branch string) (string, error) {
-	if p.branchesCfg.DisableIssue || p.messageCfg.Issue.Regex == "" {
+	if p.branchesCfg.DisableIssue {
 		return "", nil
 	}
-
-	rstr := fmt.Sprintf("^%s(%s)%s$", p.branchesCfg.PrefixRegex, p.messageCfg.Issue.Regex, p.branchesCfg.SuffixRegex)
-	r, err := regexp.Compile(rstr)
-	if err != nil {
-		return "", fmt.Errorf("could not compile issue regex: %s, error: %v", rstr, err.Error())
+	if issue, _, found, err := p.trackerIssue(branch); err != nil || found {
+		return issue, err
 	}
 
-	groups := r.FindStringSubmatch(branch)
-	if len(groups) != 4 {
-		return "", nil
+	for _, issueRegex := range p.messageCfg.Issue.Regex {
+		rstr := fmt.Sprintf("^%s(%s)%s$", p.branchesCfg.PrefixRegex, issueRegex, p.branchesCfg.SuffixRegex)
+		r, err := regexp.Compile(rstr)
+		if err != nil {
+			return "", fmt.Errorf("could not compile issue regex: %s, error: %v", rstr, err.Error())
+		}
+
+		if groups := r.FindStringSubmatch(branch); len(groups) == 4 {
+			return groups[2], nil
+		}
 	}
-	return groups[2], nil
+	return "", nil
 }
 
 // Format a commit message returning header, body and footer.
@@ -155,18 +436,24 @@ func (p MessageProcessorImpl) Format(msg CommitMessage) (string, string, string)
 	header.WriteString(": ")
 	header.WriteString(msg.Description)
 
+	issueInFooter := true
+	if issue, exists := msg.Metadata[issueMetadataKey]; exists && p.messageCfg.Issue.IsSubjectSuffix() {
+		header.WriteString(" " + formatIssueSuffix(p.messageCfg.IssueFooterConfig(), issue))
+		issueInFooter = false
+	}
+
 	var footer strings.Builder
 	if msg.BreakingMessage() != "" {
-		footer.WriteString(fmt.Sprintf("%s: %s", breakingChangeFooterKey, msg.BreakingMessage()))
+		footer.WriteString(fmt.Sprintf("%s: %s", p.messageCfg.BreakingChangeFooterConfig().Key, msg.BreakingMessage()))
 	}
-	if issue, exists := msg.Metadata[issueMetadataKey]; exists && p.messageCfg.IssueFooterConfig().Key != "" {
+	if issue, exists := msg.Metadata[issueMetadataKey]; exists && issueInFooter && p.messageCfg.IssueFooterConfig().Key != "" {
 		if footer.Len() > 0 {
 			footer.WriteString("\n")
 		}
 		footer.WriteString(formatIssueFooter(p.messageCfg.IssueFooterConfig(), issue))
 	}
 
-	return header.String(), msg.Body, footer.String()
+	return header.String(), wrapBody(msg.Body, p.messageCfg.Body.WrapWidth), footer.String()
 }
 
 // Parse a commit message.
@@ -174,18 +461,38 @@ func (p MessageProcessorImpl) Parse(subject, body string) CommitMessage {
 	commitType, scope, description, hasBreakingChange := parseSubjectMessage(subject)
 
 	metadata := make(map[string]string)
+	var pullRequest string
+	if p.messageCfg.Issue.IsSubjectSuffix() {
+		// issue suffix has precedence, pull request is only parsed if issue is before it, eg.: "add button (JIRA-1) (#123)"
+		var issue string
+		if description, issue = p.splitSubjectIssue(description); issue == "" {
+			description, pullRequest = subjectPullRequest(description)
+			description, issue = p.splitSubjectIssue(description)
+		}
+		if issue != "" {
+			metadata[issueMetadataKey] = issue
+		}
+	} else {
+		description, pullRequest = subjectPullRequest(description)
+	}
 	for key, mdCfg := range p.messageCfg.Footer {
-		if mdCfg.Key != "" {
-			prefixes := append([]string{mdCfg.Key}, mdCfg.KeySynonyms...)
-			for _, prefix := range prefixes {
-				if tagValue := extractFooterMetadata(prefix, body, mdCfg.UseHash); tagValue != "" {
-					metadata[key] = tagValue
+		if mdCfg.Key != "" && key != breakingChangeMetadataKey && !(key == issueMetadataKey && p.messageCfg.Issue.IsSubjectSuffix()) {
+			if tagValue := extractFooterMetadataFromKeys(mdCfg, body); tagValue != "" {
+				metadata[key] = tagValue
+			}
+		}
+	}
+	if _, exists := metadata[issueMetadataKey]; !exists && !p.messageCfg.Issue.IsSubjectSuffix() {
+		for _, name := range sortedTrackers(p.branchesCfg.Trackers) {
+			if footerCfg := p.branchesCfg.Trackers[name].Footer; footerCfg.Key != "" {
+				if tagValue := extractFooterMetadataFromKeys(footerCfg, body); tagValue != "" {
+					metadata[issueMetadataKey] = tagValue
 					break
 				}
 			}
 		}
 	}
-	if tagValue := extractFooterMetadata(breakingChangeFooterKey, body, false); tagValue != "" {
+	if tagValue := extractFooterMetadataFromKeys(p.messageCfg.BreakingChangeFooterConfig(), body); tagValue != "" {
 		metadata[breakingChangeMetadataKey] = tagValue
 		hasBreakingChange = true
 	}
@@ -196,10 +503,30 @@ func (p MessageProcessorImpl) Parse(subject, body string) CommitMessage {
 		Description:      description,
 		Body:             body,
 		IsBreakingChange: hasBreakingChange,
+		PullRequest:      pullRequest,
 		Metadata:         metadata,
 	}
 }
 
+// splitSubjectIssue split issue suffix from subject, eg.: "add button (JIRA-1)" to "add button" and "JIRA-1".
+func (p MessageProcessorImpl) splitSubjectIssue(subject string) (string, string) {
+	issue := subjectIssue(subject, p.subjectIssueRegexes)
+	if issue == "" {
+		return subject, ""
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(subject), "("+issue+")")), issue
+}
+
+// subjectPullRequest split pull request number from subject, eg.: "add button (#123)" to "add button" and "123".
+func subjectPullRequest(subject string) (string, string) {
+	result := pullRequestSuffixRegex.FindStringSubmatchIndex(strings.TrimSpace(subject))
+	if result == nil {
+		return subject, ""
+	}
+	subject = strings.TrimSpace(subject)
+	return strings.TrimSpace(subject[:result[0]]), subject[result[2]:result[3]]
+}
+
 func parseSubjectMessage(message string) (string, string, string, bool) {
 	regex := regexp.MustCompile("([a-z]+)(\\((.*)\\))?(!)?: (.*)")
 	result := regex.FindStringSubmatch(message)
@@ -209,12 +536,25 @@ func parseSubjectMessage(message string) (string, string, string, bool) {
 	return result[1], result[3], strings.TrimSpace(result[5]), result[4] == "!"
 }
 
+func footerKeys(cfg CommitMessageFooterConfig) []string {
+	return append([]string{cfg.Key}, cfg.KeySynonyms...)
+}
+
+func extractFooterMetadataFromKeys(cfg CommitMessageFooterConfig, text string) string {
+	for _, key := range footerKeys(cfg) {
+		if tagValue := extractFooterMetadata(key, text, cfg.HasHashSeparator()); tagValue != "" {
+			return tagValue
+		}
+	}
+	return ""
+}
+
 func extractFooterMetadata(key, text string, useHash bool) string {
 	var regex *regexp.Regexp
 	if useHash {
-		regex = regexp.MustCompile(key + " (#.*)")
+		regex = regexp.MustCompile("(?m)^" + regexp.QuoteMeta(key) + " ([#!].*)")
 	} else {
-		regex = regexp.MustCompile(key + ": (.*)")
+		regex = regexp.MustCompile("(?m)^" + regexp.QuoteMeta(key) + ": (.*)")
 	}
 
 	result := regex.FindStringSubmatch(text)
@@ -224,8 +564,12 @@ func extractFooterMetadata(key, text string, useHash bool) string {
 	return result[1]
 }
 
-func hasFooter(message string) bool {
-	r := regexp.MustCompile("^[a-zA-Z-]+: .*|^[a-zA-Z-]+ #.*|^" + breakingChangeFooterKey + ": .*")
+func hasFooter(message string, breakingChangeKeys ...string) bool {
+	pattern := "^[a-zA-Z-]+: .*|^[a-zA-Z-]+ [#!].*|^" + breakingChangeFooterKey + ": .*"
+	for _, key := range breakingChangeKeys {
+		pattern = pattern + "|^" + regexp.QuoteMeta(key) + ": .*"
+	}
+	r := regexp.MustCompile(pattern)
 
 	scanner := bufio.NewScanner(strings.NewReader(message))
 	lines := 0
@@ -240,13 +584,18 @@ func hasFooter(message string) bool {
 }
 
 func hasIssueID(message string, issueConfig CommitMessageFooterConfig) bool {
-	var r *regexp.Regexp
-	if issueConfig.UseHash {
-		r = regexp.MustCompile(fmt.Sprintf("(?m)^%s #.+$", issueConfig.Key))
-	} else {
-		r = regexp.MustCompile(fmt.Sprintf("(?m)^%s: .+$", issueConfig.Key))
+	for _, key := range footerKeys(issueConfig) {
+		var r *regexp.Regexp
+		if issueConfig.HasHashSeparator() {
+			r = regexp.MustCompile(fmt.Sprintf("(?m)^%s [#!].+$", regexp.QuoteMeta(key)))
+		} else {
+			r = regexp.MustCompile(fmt.Sprintf("(?m)^%s: .+$", regexp.QuoteMeta(key)))
+		}
+		if r.MatchString(message) {
+			return true
+		}
 	}
-	return r.MatchString(message)
+	return false
 }
 
 func contains(value string, content []string) bool {
@@ -258,6 +607,16 @@ func contains(value string, content []string) bool {
 	return false
 }
 
+// matchesAny check if value fully matches one of the regexes, invalid regexes are ignored, they are rejected on config load.
+func matchesAny(value string, regexes []string) bool {
+	for _, r := range regexes {
+		if matched, err := regexp.MatchString("^(?:"+r+")$", value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 func splitCommitMessageContent(content string) (string, string) {
 	scanner := bufio.NewScanner(strings.NewReader(content))
 
@@ -276,3 +635,185 @@ func splitCommitMessageContent(content string) (string, string) {
 
 	return subject, body.String()
 }
+
+func (p MessageProcessorImpl) footerProblems(body string) []ValidationProblem {
+	var tokens []string
+	if len(p.messageCfg.FooterValidation.Tokens) > 0 {
+		tokens = append(tokens, p.messageCfg.FooterValidation.Tokens...)
+		for _, cfg := range p.messageCfg.Footer {
+			tokens = append(tokens, footerKeys(cfg)...)
+		}
+		tokens = append(tokens, footerKeys(p.messageCfg.BreakingChangeFooterConfig())...)
+		for _, tracker := range p.branchesCfg.Trackers {
+			tokens = append(tokens, footerKeys(tracker.Footer)...)
+		}
+	}
+
+	footer := footerLines(body)
+	firstLine := lastNonBlankLine(body) - len(footer) + 2
+	footers, starts, orphans := parseFooterLines(footer)
+	var problems []ValidationProblem
+	for _, i := range orphans {
+		problems = append(problems, ValidationProblem{RuleFooterFormat, fmt.Sprintf("footer [%s] should follow \"Key: value\" or \"Key #value\" format", footer[i]), firstLine + i, 1})
+	}
+	for i, f := range footers {
+		if len(tokens) > 0 && !contains(f.Key, tokens) {
+			problems = append(problems, ValidationProblem{RuleFooterToken, fmt.Sprintf("footer token [%s] should be one of [%v]", f.Key, strings.Join(tokens, ", ")), firstLine + starts[i], 1})
+		}
+	}
+	return problems
+}
+
+// breakingChangeProblems report breaking change footers without description, eg.: "BREAKING CHANGE:", body starts on message line 2.
+func (p MessageProcessorImpl) breakingChangeProblems(body string) []ValidationProblem {
+	keys := footerKeys(p.messageCfg.BreakingChangeFooterConfig())
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = regexp.QuoteMeta(key)
+	}
+	r := regexp.MustCompile(`^(` + strings.Join(quoted, "|") + `):\s*$`)
+
+	var problems []ValidationProblem
+	for i, line := range strings.Split(body, "\n") {
+		if result := r.FindStringSubmatch(line); result != nil {
+			problems = append(problems, ValidationProblem{RuleBreakingChangeEmpty, fmt.Sprintf("breaking change footer [%s] should have a description", result[1]), i + 2, utf8.RuneCountInString(result[1]) + 2})
+		}
+	}
+	return problems
+}
+
+// lastNonBlankLine return the number of body lines ignoring trailing blank lines.
+func lastNonBlankLine(body string) int {
+	lines := strings.Split(body, "\n")
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return end
+}
+
+// footerLines return last body paragraph lines if it contains a footer, otherwise return nil.
+func footerLines(body string) []string {
+	paragraphs := paragraphSeparatorRegex.Split(strings.TrimSpace(body), -1)
+	lines := strings.Split(paragraphs[len(paragraphs)-1], "\n")
+	for _, line := range lines {
+		if footerRegex.MatchString(line) {
+			return lines
+		}
+	}
+	return nil
+}
+
+// parseFooterLines group footer lines into footers, lines that aren't footer tokens continue the previous footer value.
+// Returns footers, the line index where each footer starts and indexes of lines before the first footer.
+func parseFooterLines(lines []string) (footers []CommitMessageFooter, starts []int, orphans []int) {
+	for i, line := range lines {
+		if result := footerRegex.FindStringSubmatch(line); result != nil {
+			footers = append(footers, CommitMessageFooter{Key: result[1], Value: result[2] + result[3]})
+			starts = append(starts, i)
+			continue
+		}
+		if len(footers) == 0 {
+			orphans = append(orphans, i)
+			continue
+		}
+		footers[len(footers)-1].Value += "\n" + line
+	}
+	return footers, starts, orphans
+}
+
+// bodyLongLines return message line numbers (subject is line 1) of body lines longer than maxLength,
+// footer, git comments and lines after git scissors line (commit --verbose diff) are not checked.
+func bodyLongLines(body string, maxLength int) []int {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, scissorsLine) {
+			lines = lines[:i]
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			lines[i] = ""
+		}
+	}
+
+	end := lastNonBlankLine(strings.Join(lines, "\n"))
+	end -= len(footerLines(strings.Join(lines[:end], "\n")))
+
+	var result []int
+	for i := 0; i < end; i++ {
+		if utf8.RuneCountInString(lines[i]) > maxLength {
+			result = append(result, i+2)
+		}
+	}
+	return result
+}
+
+// ScopeRegex convert scope values to a regex matching any of them, values can use "*" to match any characters and "?" to match a single character, eg.: api-*.
+func ScopeRegex(values []string) string {
+	patterns := make([]string, len(values))
+	for i, v := range values {
+		patterns[i] = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(v))
+	}
+	return "^(" + strings.Join(patterns, "|") + ")$"
+}
+
+// HasScopePattern check if any scope value uses wildcards.
+func HasScopePattern(values []string) bool {
+	for _, v := range values {
+		if strings.ContainsAny(v, "*?") {
+			return true
+		}
+	}
+	return false
+}
+
+func startsWithUpper(value string) bool {
+	for _, r := range value {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// wrapBody wrap body lines at width, if width is zero or less, body is not changed.
+// Code blocks (fenced or indented) and words longer than width, like urls, are kept unwrapped.
+func wrapBody(body string, width int) string {
+	if width <= 0 || body == "" {
+		return body
+	}
+
+	var lines []string
+	inCodeBlock := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			lines = append(lines, line)
+			continue
+		}
+		if inCodeBlock || strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+			lines = append(lines, line)
+			continue
+		}
+		lines = append(lines, wrapLine(line, width)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func wrapLine(line string, width int) []string {
+	words := strings.Fields(line)
+	if utf8.RuneCountInString(line) <= width || len(words) == 0 {
+		return []string{line}
+	}
+
+	var lines []string
+	current, currentWidth := words[0], utf8.RuneCountInString(words[0])
+	for _, word := range words[1:] {
+		wordWidth := utf8.RuneCountInString(word)
+		if currentWidth+1+wordWidth > width {
+			lines = append(lines, current)
+			current, currentWidth = word, wordWidth
+			continue
+		}
+		current, currentWidth = current+" "+word, currentWidth+1+wordWidth
+	}
+	return append(lines, current)
+}
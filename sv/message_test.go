@@ -2,6 +2,7 @@ package sv
 
 import (
 	"reflect"
+	"regexp"
 	"testing"
 )
 
@@ -12,7 +13,7 @@ var ccfg = CommitMessageConfig{
 		"issue": {Key: "jira", KeySynonyms: []string{"Jira"}},
 		"refs":  {Key: "Refs", UseHash: true},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"[A-Z]+-[0-9]+"}},
 }
 
 var ccfgHash = CommitMessageConfig{
@@ -22,7 +23,16 @@ var ccfgHash = CommitMessageConfig{
 		"issue": {Key: "jira", KeySynonyms: []string{"Jira"}, UseHash: true},
 		"refs":  {Key: "Refs", UseHash: true},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"[A-Z]+-[0-9]+"}},
+}
+
+var ccfgSeparator = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Scope: CommitMessageScopeConfig{},
+	Footer: map[string]CommitMessageFooterConfig{
+		"issue": {Key: "Refs", Separator: FooterSeparatorHash},
+	},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"#?[0-9]+"}},
 }
 
 var ccfgGitIssue = CommitMessageConfig{
@@ -31,7 +41,16 @@ var ccfgGitIssue = CommitMessageConfig{
 	Footer: map[string]CommitMessageFooterConfig{
 		"issue": {Key: "issue", KeySynonyms: []string{"Issue"}, UseHash: false, AddValuePrefix: "#"},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "#?[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"#?[0-9]+"}},
+}
+
+var ccfgSubjectIssue = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Scope: CommitMessageScopeConfig{},
+	Footer: map[string]CommitMessageFooterConfig{
+		"issue": {Key: "issue", AddValuePrefix: "#"},
+	},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"#?[0-9]+"}, Placement: IssuePlacementSubjectSuffix},
 }
 
 var ccfgEmptyIssue = CommitMessageConfig{
@@ -40,7 +59,7 @@ var ccfgEmptyIssue = CommitMessageConfig{
 	Footer: map[string]CommitMessageFooterConfig{
 		"issue": {},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"[A-Z]+-[0-9]+"}},
 }
 
 var ccfgWithScope = CommitMessageConfig{
@@ -50,7 +69,53 @@ var ccfgWithScope = CommitMessageConfig{
 		"issue": {Key: "jira", KeySynonyms: []string{"Jira"}},
 		"refs":  {Key: "Refs", UseHash: true},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"[A-Z]+-[0-9]+"}},
+}
+
+var ccfgGitLab = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Scope: CommitMessageScopeConfig{},
+	Footer: map[string]CommitMessageFooterConfig{
+		"issue": {Key: "Closes", UseHash: true},
+	},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"[#!]?[0-9]+"}},
+}
+
+var ccfgBreakingChange = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Scope: CommitMessageScopeConfig{},
+	Footer: map[string]CommitMessageFooterConfig{
+		"issue":           {Key: "jira", KeySynonyms: []string{"Jira"}},
+		"breaking-change": {Key: "BREAKING-CHANGE", KeySynonyms: []string{"QUEBRA"}},
+	},
+	Issue: CommitMessageIssueConfig{Regex: StringList{"[A-Z]+-[0-9]+"}},
+}
+
+var ccfgBreakingChangeCustomKey = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Footer: map[string]CommitMessageFooterConfig{
+		"breaking-change": {Key: "QUEBRA"},
+	},
+}
+
+var ccfgFooterValidation = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Scope: CommitMessageScopeConfig{},
+	Footer: map[string]CommitMessageFooterConfig{
+		"issue": {Key: "jira", KeySynonyms: []string{"Jira"}},
+	},
+	FooterValidation: CommitMessageFooterValidationConfig{Enabled: true},
+	Issue:            CommitMessageIssueConfig{Regex: StringList{"[A-Z]+-[0-9]+"}},
+}
+
+var ccfgFooterTokens = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Scope: CommitMessageScopeConfig{},
+	Footer: map[string]CommitMessageFooterConfig{
+		"issue": {Key: "jira", KeySynonyms: []string{"Jira"}},
+	},
+	FooterValidation: CommitMessageFooterValidationConfig{Enabled: true, Tokens: []string{"Reviewed-by"}},
+	Issue:            CommitMessageIssueConfig{Regex: StringList{"[A-Z]+-[0-9]+"}},
 }
 
 func newBranchCfg(skipDetached bool) BranchesConfig {
@@ -111,6 +176,9 @@ func TestMessageProcessorImpl_SkipBranch(t *testing.T) {
 		{"ignore branch on skip list", newBranchCfg(false), "master", false, true},
 		{"ignore detached branch", newBranchCfg(true), "JIRA-123", true, true},
 		{"null skip detached", BranchesConfig{Skip: []string{}}, "JIRA-123", true, false},
+		{"ignore branch matching skip regex", BranchesConfig{SkipRegex: []string{"dependabot/.*", "renovate/.*"}}, "dependabot/go_modules/foo", false, true},
+		{"dont ignore branch partially matching skip regex", BranchesConfig{SkipRegex: []string{"dependabot"}}, "feature/dependabot-JIRA-123", false, false},
+		{"ignore invalid skip regex", BranchesConfig{SkipRegex: []string{"dependabot/("}}, "dependabot/(", false, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -133,8 +201,15 @@ func TestMessageProcessorImpl_Validate(t *testing.T) {
 		{"single line valid message with scope", ccfg, "feat(scope): add something", false},
 		{"single line valid scope from list", ccfgWithScope, "feat(scope): add something", false},
 		{"single line invalid scope from list", ccfgWithScope, "feat(invalid): add something", true},
+		{"scope matching wildcard", CommitMessageConfig{Types: []string{"feat"}, Scope: CommitMessageScopeConfig{Values: []string{"api-*", "web"}}}, "feat(api-users): add something", false},
+		{"scope not matching wildcard", CommitMessageConfig{Types: []string{"feat"}, Scope: CommitMessageScopeConfig{Values: []string{"api-*", "web"}}}, "feat(web-users): add something", true},
+		{"required scope", CommitMessageConfig{Types: []string{"feat"}, Scope: CommitMessageScopeConfig{Required: true}}, "feat(any): add something", false},
+		{"missing required scope", CommitMessageConfig{Types: []string{"feat"}, Scope: CommitMessageScopeConfig{Required: true}}, "feat: add something", true},
 		{"single line invalid type message", ccfg, "something: add something", true},
 		{"single line invalid type message", ccfg, "feat?: add something", true},
+		{"empty subject", ccfg, "feat: ", true},
+		{"whitespace only subject", ccfg, "feat(scope):   ", true},
+		{"missing subject", ccfg, "feat:", true},
 
 		{"multi line valid message", ccfg, `feat: add something
 		
@@ -146,6 +221,31 @@ func TestMessageProcessorImpl_Validate(t *testing.T) {
 
 		{"support ! for breaking change", ccfg, "feat!: add something", false},
 		{"support ! with scope for breaking change", ccfg, "feat(scope)!: add something", false},
+		{"denied type", CommitMessageConfig{Types: []string{"feat", "wip"}, DeniedTypes: []string{"wip"}}, "wip: add something", true},
+		{"type not denied", CommitMessageConfig{Types: []string{"feat", "wip"}, DeniedTypes: []string{"wip"}}, "feat: add something", false},
+		{"denied type not listed in types", CommitMessageConfig{Types: []string{"feat"}, DeniedTypes: []string{"wip"}}, "wip: add something", true},
+		{"lowercase subject", CommitMessageConfig{Types: []string{"feat"}, Subject: CommitMessageSubjectConfig{Lowercase: true}}, "feat: add API", false},
+		{"capitalized subject", CommitMessageConfig{Types: []string{"feat"}, Subject: CommitMessageSubjectConfig{Lowercase: true}}, "feat: Add something", true},
+		{"capitalized subject without lowercase check", CommitMessageConfig{Types: []string{"feat"}}, "feat: Add something", false},
+		{"subject without trailing period", CommitMessageConfig{Types: []string{"feat"}, Subject: CommitMessageSubjectConfig{NoTrailingPeriod: true}}, "feat: add something", false},
+		{"subject with trailing period", CommitMessageConfig{Types: []string{"feat"}, Subject: CommitMessageSubjectConfig{NoTrailingPeriod: true}}, "feat: add something.", true},
+		{"valid footers", ccfgFooterValidation, "feat: add something\n\nbody\n\njira: JIRA-123\nRefs #45", false},
+		{"footer missing colon", ccfgFooterValidation, "feat: add something\n\nRefs 45\njira: JIRA-123", true},
+		{"footer missing colon without validation", ccfg, "feat: add something\n\nRefs 45\njira: JIRA-123", false},
+		{"multi line breaking change footer", ccfgFooterValidation, "feat: add something\n\nbody\n\nBREAKING CHANGE: breaks\nthe whole api\njira: JIRA-123", false},
+		{"body without footer", ccfgFooterValidation, "feat: add something\n\nsome body text", false},
+		{"known footer token", ccfgFooterTokens, "feat: add something\n\njira: JIRA-123\nReviewed-by: someone", false},
+		{"unknown footer token", ccfgFooterTokens, "feat: add something\n\nUnknown: value", true},
+		{"body lines within max length", CommitMessageConfig{Types: []string{"feat"}, Body: CommitMessageBodyConfig{MaxLineLength: 10}}, "feat: add something\n\nshort body\n", false},
+		{"body line exceeds max length", CommitMessageConfig{Types: []string{"feat"}, Body: CommitMessageBodyConfig{MaxLineLength: 10}}, "feat: add something\n\nbody line too long", true},
+		{"long footer is not checked", CommitMessageConfig{Types: []string{"feat"}, Body: CommitMessageBodyConfig{MaxLineLength: 10}}, "feat: add something\n\nbody\n\nReviewed-by: someone with a long name", false},
+		{"breaking change with description", ccfg, "feat: add something\n\nBREAKING CHANGE: removed x", false},
+		{"breaking change without description", ccfg, "feat: add something\n\nBREAKING CHANGE:", true},
+		{"breaking change synonym without description", ccfgBreakingChange, "feat: add something\n\nQUEBRA: ", true},
+		{"fixup commit", ccfg, "fixup! feat: add something", true},
+		{"squash commit", ccfg, "squash! feat: add something", true},
+		{"allowed fixup commit", CommitMessageConfig{Types: []string{"feat"}, AllowFixup: true}, "fixup! feat: add something", false},
+		{"allowed fixup commit with invalid message", CommitMessageConfig{Types: []string{"feat"}, AllowFixup: true}, "fixup! add something", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -157,6 +257,154 @@ func TestMessageProcessorImpl_Validate(t *testing.T) {
 	}
 }
 
+func TestMessageProcessorImpl_ValidationProblems(t *testing.T) {
+	strict := CommitMessageConfig{
+		Types:   []string{"feat"},
+		Subject: CommitMessageSubjectConfig{Lowercase: true, NoTrailingPeriod: true},
+		Body:    CommitMessageBodyConfig{MaxLineLength: 10},
+	}
+	tests := []struct {
+		name    string
+		cfg     CommitMessageConfig
+		message string
+		want    []ValidationProblem
+	}{
+		{"valid message", ccfg, "feat: add something", nil},
+		{"empty subject", strict, "feat:  ", []ValidationProblem{{RuleSubjectEmpty, "message description should not be empty, subject: [feat:  ]", 1, 8}}},
+		{"invalid header", strict, "Add something.", []ValidationProblem{{RuleHeaderFormat, "subject [Add something.] should be valid according with conventional commits", 1, 1}}},
+		{"invalid header with hint", CommitMessageConfig{Types: []string{"feat"}, HeaderHint: "expected: <type>(<scope>): <subject>, eg.: feat(api): add endpoint"}, "Add something", []ValidationProblem{{RuleHeaderFormat, "subject [Add something] should be valid according with conventional commits, expected: <type>(<scope>): <subject>, eg.: feat(api): add endpoint", 1, 1}}},
+		{"empty breaking change", ccfg, "feat: add something\n\nbody\n\nBREAKING CHANGE: ", []ValidationProblem{{RuleBreakingChangeEmpty, "breaking change footer [BREAKING CHANGE] should have a description", 5, 17}}},
+		{"empty spec breaking change synonym", ccfg, "feat: add something\n\nbody\n\nBREAKING-CHANGE: ", []ValidationProblem{{RuleBreakingChangeEmpty, "breaking change footer [BREAKING-CHANGE] should have a description", 5, 17}}},
+		{"fixup commit", strict, "fixup! feat: add something", []ValidationProblem{{RuleFixup, "fixup! commits should be squashed before merging, subject: [fixup! feat: add something]", 1, 1}}},
+		{"multiple problems", strict, "feat: Add something.\n\nbody line too long\nshort\nanother long line", []ValidationProblem{
+			{RuleSubjectCase, "message description [Add something.] should not start with an uppercase letter", 1, 7},
+			{RuleSubjectFullStop, "message description [Add something.] should not end with a period", 1, 20},
+			{RuleBodyMaxLineLength, "body line 3 should not exceed 10 characters", 3, 11},
+			{RuleBodyMaxLineLength, "body line 5 should not exceed 10 characters", 5, 11},
+		}},
+		{"invalid type and scope", ccfgWithScope, "docs(invalid): add something", []ValidationProblem{
+			{RuleTypeEnum, "message type should be one of [feat, fix]", 1, 1},
+			{RuleScopeEnum, "message scope should one of [, scope]", 1, 5},
+		}},
+		{"denied type not listed in types", CommitMessageConfig{Types: []string{"feat"}, DeniedTypes: []string{"wip"}}, "wip: add something", []ValidationProblem{
+			{RuleTypeDenied, "message type [wip] is denied by policy, denied types: [wip]", 1, 1},
+		}},
+		{"footer problems", ccfgFooterTokens, "feat: add something\n\nbody\n\nRefs 45\njira: JIRA-123\nUnknown: value\ncontinued value\n", []ValidationProblem{
+			{RuleFooterFormat, "footer [Refs 45] should follow \"Key: value\" or \"Key #value\" format", 5, 1},
+			{RuleFooterToken, "footer token [Unknown] should be one of [Reviewed-by, jira, Jira, BREAKING CHANGE, BREAKING-CHANGE]", 7, 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewMessageProcessor(tt.cfg, newBranchCfg(false))
+			if got := p.ValidationProblems(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MessageProcessorImpl.ValidationProblems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageProcessorImpl_HeaderProblems(t *testing.T) {
+	strict := CommitMessageConfig{
+		Types:      []string{"feat"},
+		Subject:    CommitMessageSubjectConfig{Lowercase: true},
+		Body:       CommitMessageBodyConfig{MaxLineLength: 10},
+		AllowFixup: true,
+	}
+	tests := []struct {
+		name    string
+		message string
+		want    []ValidationProblem
+	}{
+		{"valid header with invalid body", "feat: add something\n\nbody line too long\n\nBREAKING CHANGE: ", nil},
+		{"invalid header", "Add something", []ValidationProblem{{RuleHeaderFormat, "subject [Add something] should be valid according with conventional commits", 1, 1}}},
+		{"allowed fixup", "fixup! feat: Add something", []ValidationProblem{{RuleSubjectCase, "message description [Add something] should not start with an uppercase letter", 1, 7}}},
+		{"invalid type", "fix: add something\n\nbody line too long", []ValidationProblem{{RuleTypeEnum, "message type should be one of [feat]", 1, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewMessageProcessor(strict, newBranchCfg(false))
+			if got := p.HeaderProblems(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MessageProcessorImpl.HeaderProblems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_bodyLongLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		maxLength int
+		want      []int
+	}{
+		{"empty body", "", 10, nil},
+		{"short lines", "\nshort\nlines", 10, nil},
+		{"long lines", "\nthis line is long\nshort\nanother long line", 10, []int{3, 5}},
+		{"ignore footer", "\nthis line is long\n\nReviewed-by: someone with a long name\n", 10, []int{3}},
+		{"footer only", "\nReviewed-by: someone with a long name", 10, nil},
+		{"count characters", "\náéíóúáéíóú", 10, nil},
+		{"ignore git comments", "\nReviewed-by: someone\n\n# Please enter the commit message for your changes.", 10, nil},
+		{"ignore verbose diff", "\nshort\n# ------------------------ >8 ------------------------\ndiff --git a/main.go b/main.go", 10, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bodyLongLines(tt.body, tt.maxLength); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bodyLongLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_wrapBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		width int
+		want  string
+	}{
+		{"disabled", "a long line that should not be wrapped", 0, "a long line that should not be wrapped"},
+		{"short line", "short line", 20, "short line"},
+		{"wrap line", "a long line that should be wrapped", 12, "a long line\nthat should\nbe wrapped"},
+		{"keep line breaks", "first line\n\nsecond line", 12, "first line\n\nsecond line"},
+		{"keep urls", "see https://example.com/a/very/long/url for details", 12, "see\nhttps://example.com/a/very/long/url\nfor details"},
+		{"keep fenced code block", "```\nfunc main() { fmt.Println(\"hello\") }\n```", 12, "```\nfunc main() { fmt.Println(\"hello\") }\n```"},
+		{"keep indented code block", "    func main() { fmt.Println(\"hello\") }", 12, "    func main() { fmt.Println(\"hello\") }"},
+		{"multibyte short line", "ação à função", 13, "ação à função"},
+		{"wrap multibyte line", "ação não é função válida", 12, "ação não é\nfunção\nválida"},
+		{"wrap cjk line", "日本語 の テキスト", 8, "日本語 の\nテキスト"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapBody(tt.body, tt.width); got != tt.want {
+				t.Errorf("wrapBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitMessage_Footers(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []CommitMessageFooter
+	}{
+		{"empty body", "", nil},
+		{"body without footer", "some body text", nil},
+		{"single footer", "jira: JIRA-123", []CommitMessageFooter{{Key: "jira", Value: "JIRA-123"}}},
+		{"footers after body", "some body text\n\njira: JIRA-123\nRefs #45", []CommitMessageFooter{{Key: "jira", Value: "JIRA-123"}, {Key: "Refs", Value: "#45"}}},
+		{"breaking change", "BREAKING CHANGE: breaks\nReviewed-by: someone", []CommitMessageFooter{{Key: "BREAKING CHANGE", Value: "breaks"}, {Key: "Reviewed-by", Value: "someone"}}},
+		{"multiline value", "BREAKING CHANGE: breaks\nsomething else", []CommitMessageFooter{{Key: "BREAKING CHANGE", Value: "breaks\nsomething else"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (CommitMessage{Body: tt.body}).Footers(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommitMessage.Footers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMessageProcessorImpl_Enhance(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -178,6 +426,9 @@ func TestMessageProcessorImpl_Enhance(t *testing.T) {
 		{"numeric issue on branch name", ccfgGitIssue, "#13", "fix: fix something", "\nissue: #13", false},
 		{"numeric issue on branch name without hash", ccfgGitIssue, "13", "fix: fix something", "\nissue: #13", false},
 		{"numeric issue on branch name with description without hash", ccfgGitIssue, "13-some-fix", "fix: fix something", "\nissue: #13", false},
+		{"issue on subject suffix", ccfgSubjectIssue, "13-some-fix", "fix: fix something", " (#13)", false},
+		{"issue already on subject suffix", ccfgSubjectIssue, "13-some-fix", "fix: fix something (#13)", "", false},
+		{"no issue on branch name for subject suffix", ccfgSubjectIssue, "branch", "fix: fix something", "", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -193,6 +444,46 @@ func TestMessageProcessorImpl_Enhance(t *testing.T) {
 	}
 }
 
+func TestMessageProcessorImpl_Enhance_Trackers(t *testing.T) {
+	branchCfg := newBranchCfg(false)
+	branchCfg.Trackers = map[string]BranchTrackerConfig{
+		"jira": {Regex: StringList{"[A-Z]+-[0-9]+"}, Footer: CommitMessageFooterConfig{Key: "Jira"}},
+		"gh":   {Regex: StringList{"[0-9]+"}, Footer: CommitMessageFooterConfig{Key: "Refs", Separator: FooterSeparatorHash}},
+	}
+	p := NewMessageProcessor(ccfg, branchCfg)
+
+	if got := p.Parse("fix: fix something", "Refs #42").Metadata[issueMetadataKey]; got != "#42" {
+		t.Errorf("MessageProcessorImpl.Parse() issue = %v, want #42", got)
+	}
+
+	tests := []struct {
+		name    string
+		branch  string
+		message string
+		want    string
+		wantErr bool
+	}{
+		{"jira tracker", "jira/PROJ-1", "fix: fix something", "\nJira: PROJ-1", false},
+		{"jira tracker with description", "jira/PROJ-1-some-description", "fix: fix something", "\nJira: PROJ-1", false},
+		{"github tracker", "gh/42", "fix: fix something", "\nRefs #42", false},
+		{"github tracker with issue on footer", "gh/42", "fix: fix something\n\nRefs #42", "", false},
+		{"without tracker prefix", "feature/JIRA-123", "fix: fix something", "\njira: JIRA-123", false},
+		{"tracker without issue", "gh/some-fix", "fix: fix something", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Enhance(tt.branch, tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MessageProcessorImpl.Enhance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MessageProcessorImpl.Enhance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMessageProcessorImpl_IssueID(t *testing.T) {
 	p := NewMessageProcessor(ccfg, newBranchCfg(false))
 
@@ -223,6 +514,122 @@ func TestMessageProcessorImpl_IssueID(t *testing.T) {
 	}
 }
 
+func TestMessageProcessorImpl_IssueID_MultipleRegexes(t *testing.T) {
+	cfg := CommitMessageConfig{Issue: CommitMessageIssueConfig{Regex: StringList{"[A-Z]+-[0-9]+", "[0-9]+"}}}
+	p := NewMessageProcessor(cfg, newBranchCfg(false))
+
+	tests := []struct {
+		name   string
+		branch string
+		want   string
+	}{
+		{"first regex", "feature/JIRA-123-some-description", "JIRA-123"},
+		{"second regex", "feature/456-some-description", "456"},
+		{"no match", "feature/some-description", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.IssueID(tt.branch)
+			if err != nil {
+				t.Errorf("MessageProcessorImpl.IssueID() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MessageProcessorImpl.IssueID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeRegex(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		scope  string
+		want   bool
+	}{
+		{"exact value", []string{"api", "web"}, "web", true},
+		{"partial value", []string{"api", "web"}, "webapp", false},
+		{"empty value allowed", []string{"", "api"}, "", true},
+		{"star wildcard", []string{"api-*"}, "api-users", true},
+		{"star wildcard prefix", []string{"api-*"}, "internal-api-users", false},
+		{"question mark wildcard", []string{"v?"}, "v2", true},
+		{"question mark single character", []string{"v?"}, "v10", false},
+		{"regex characters are literal", []string{"a.b"}, "axb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := regexp.MustCompile(ScopeRegex(tt.values)).MatchString(tt.scope); got != tt.want {
+				t.Errorf("ScopeRegex() match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_subjectIssue(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		regexes []string
+		want    string
+	}{
+		{"any value", "feat: something (abc)", nil, "abc"},
+		{"single regex", "feat: something (#123)", []string{"#?[0-9]+"}, "#123"},
+		{"second regex", "feat: something (PROJ-123)", []string{"#?[0-9]+", "[A-Z]+-[0-9]+"}, "PROJ-123"},
+		{"no match", "feat: something (abc)", []string{"#?[0-9]+", "[A-Z]+-[0-9]+"}, ""},
+		{"invalid regex skipped", "feat: something (PROJ-123)", []string{"[0-9", "[A-Z]+-[0-9]+"}, "PROJ-123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regexes, _ := subjectIssueRegexes(tt.regexes, "#")
+			if got := subjectIssue(tt.subject, regexes); got != tt.want {
+				t.Errorf("subjectIssue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_subjectIssueRegexes(t *testing.T) {
+	tests := []struct {
+		name    string
+		regexes []string
+		want    int
+		wantErr bool
+	}{
+		{"default regex", nil, 1, false},
+		{"valid regexes", []string{"#?[0-9]+", "[A-Z]+-[0-9]+"}, 2, false},
+		{"invalid regex", []string{"[0-9", "[A-Z]+-[0-9]+"}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := subjectIssueRegexes(tt.regexes, "#")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("subjectIssueRegexes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("subjectIssueRegexes() = %d regexes, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageProcessorImpl_InvalidSubjectIssueRegex(t *testing.T) {
+	cfg := CommitMessageConfig{
+		Types:  []string{"feat"},
+		Footer: map[string]CommitMessageFooterConfig{"issue": {Key: "issue", AddValuePrefix: "#"}},
+		Issue:  CommitMessageIssueConfig{Regex: StringList{"[0-9"}, Placement: "subject-suffix"},
+	}
+	p := NewMessageProcessor(cfg, newBranchCfg(false))
+
+	want := CommitMessage{Type: "feat", Description: "something (PROJ-1)", Metadata: map[string]string{}}
+	if got := p.Parse("feat: something (PROJ-1)", ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("MessageProcessorImpl.Parse() = %v, want %v", got, want)
+	}
+	if _, err := p.Enhance("JIRA-123", "feat: something"); err == nil {
+		t.Errorf("MessageProcessorImpl.Enhance() error = nil, want compilation error")
+	}
+}
+
 const (
 	multilineBody = `a
 b
@@ -234,6 +641,7 @@ jira: JIRA-123`
 func Test_hasIssueID(t *testing.T) {
 	cfgColon := CommitMessageFooterConfig{Key: "jira"}
 	cfgHash := CommitMessageFooterConfig{Key: "jira", UseHash: true}
+	cfgSeparator := CommitMessageFooterConfig{Key: "jira", KeySynonyms: []string{"Jira"}, UseHash: true, Separator: FooterSeparatorColon}
 	cfgEmpty := CommitMessageFooterConfig{}
 
 	tests := []struct {
@@ -258,6 +666,12 @@ jira #JIRA-123`, cfgHash, true},
 		{"empty config", `feat: something
 		
 jira #JIRA-123`, cfgEmpty, false},
+		{"separator overrides use hash", `feat: something
+		
+jira #JIRA-123`, cfgSeparator, false},
+		{"synonym with separator", `feat: something
+		
+Jira: JIRA-123`, cfgSeparator, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -325,7 +739,19 @@ func TestMessageProcessorImpl_Parse(t *testing.T) {
 		{"jira synonyms metadata", ccfg, "feat: something new", issueSynonymsBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: issueSynonymsBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-789"}}},
 		{"breaking change with exclamation mark", ccfg, "feat!: something new", "", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "", IsBreakingChange: true, Metadata: map[string]string{}}},
 		{"hash metadata", ccfg, "feat: something new", hashMetadataBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: hashMetadataBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-999", "refs": "#123"}}},
+		{"hash separator metadata", ccfgSeparator, "feat: something new", "body\n\nRefs #123", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "body\n\nRefs #123", IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "#123"}}},
+		{"footer key in middle of line", ccfg, "feat: something new", "body\n\nnot jira: JIRA-1", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "body\n\nnot jira: JIRA-1", IsBreakingChange: false, Metadata: map[string]string{}}},
+		{"gitlab issue metadata", ccfgGitLab, "feat: something new", "body\n\nCloses #123", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "body\n\nCloses #123", IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "#123"}}},
+		{"gitlab merge request metadata", ccfgGitLab, "feat: something new", "body\n\nCloses !45", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "body\n\nCloses !45", IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "!45"}}},
+		{"custom breaking change key", ccfgBreakingChange, "feat: something new", "body\n\nBREAKING-CHANGE: breaks", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "body\n\nBREAKING-CHANGE: breaks", IsBreakingChange: true, Metadata: map[string]string{breakingChangeMetadataKey: "breaks"}}},
+		{"custom breaking change synonym", ccfgBreakingChange, "feat: something new", "body\n\nQUEBRA: breaks", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "body\n\nQUEBRA: breaks", IsBreakingChange: true, Metadata: map[string]string{breakingChangeMetadataKey: "breaks"}}},
+		{"default breaking change key with custom config", ccfgBreakingChange, "feat: something new", "body\n\nBREAKING CHANGE: breaks", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "body\n\nBREAKING CHANGE: breaks", IsBreakingChange: true, Metadata: map[string]string{breakingChangeMetadataKey: "breaks"}}},
+		{"spec breaking change synonym with custom key", ccfgBreakingChangeCustomKey, "feat: something new", "body\n\nBREAKING-CHANGE: breaks", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "body\n\nBREAKING-CHANGE: breaks", IsBreakingChange: true, Metadata: map[string]string{breakingChangeMetadataKey: "breaks"}}},
 		{"empty issue cfg", ccfgEmptyIssue, "feat: something new", hashMetadataBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: hashMetadataBody, IsBreakingChange: false, Metadata: map[string]string{}}},
+		{"issue on subject suffix", ccfgSubjectIssue, "feat: something new (#123)", "", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "", IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "#123"}}},
+		{"pull request on subject", ccfg, "feat: something new (#123)", "", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "", IsBreakingChange: false, PullRequest: "123", Metadata: map[string]string{}}},
+		{"non numeric subject suffix", ccfg, "feat: something new (JIRA-1)", "", CommitMessage{Type: "feat", Scope: "", Description: "something new (JIRA-1)", Body: "", IsBreakingChange: false, Metadata: map[string]string{}}},
+		{"issue footer ignored on subject suffix", ccfgSubjectIssue, "feat: something new", "issue: #123", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "issue: #123", IsBreakingChange: false, Metadata: map[string]string{}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -348,6 +774,7 @@ func TestMessageProcessorImpl_Format(t *testing.T) {
 		{"simple message", ccfg, NewCommitMessage("feat", "", "something", "", "", ""), "feat: something", "", ""},
 		{"with issue", ccfg, NewCommitMessage("feat", "", "something", "", "JIRA-123", ""), "feat: something", "", "jira: JIRA-123"},
 		{"with issue using hash", ccfgHash, NewCommitMessage("feat", "", "something", "", "JIRA-123", ""), "feat: something", "", "jira #JIRA-123"},
+		{"with issue using hash separator", ccfgSeparator, NewCommitMessage("feat", "", "something", "", "123", ""), "feat: something", "", "Refs #123"},
 		{"with issue using double hash", ccfgHash, NewCommitMessage("feat", "", "something", "", "#JIRA-123", ""), "feat: something", "", "jira #JIRA-123"},
 		{"with breaking change", ccfg, NewCommitMessage("feat", "", "something", "", "", "breaks"), "feat: something", "", "BREAKING CHANGE: breaks"},
 		{"with scope", ccfg, NewCommitMessage("feat", "scope", "something", "", "", ""), "feat(scope): something", "", ""},
@@ -357,6 +784,10 @@ func TestMessageProcessorImpl_Format(t *testing.T) {
 		{"config without issue key", ccfgEmptyIssue, NewCommitMessage("feat", "", "something", "", "JIRA-123", ""), "feat: something", "", ""},
 		{"with issue and issue prefix", ccfgGitIssue, NewCommitMessage("feat", "", "something", "", "123", ""), "feat: something", "", "issue: #123"},
 		{"with #issue and issue prefix", ccfgGitIssue, NewCommitMessage("feat", "", "something", "", "#123", ""), "feat: something", "", "issue: #123"},
+		{"with custom breaking change key", ccfgBreakingChange, NewCommitMessage("feat", "", "something", "", "", "breaks"), "feat: something", "", "BREAKING-CHANGE: breaks"},
+		{"with gitlab issue", ccfgGitLab, NewCommitMessage("feat", "", "something", "", "123", ""), "feat: something", "", "Closes #123"},
+		{"with gitlab merge request", ccfgGitLab, NewCommitMessage("feat", "", "something", "", "!45", ""), "feat: something", "", "Closes !45"},
+		{"with issue on subject suffix", ccfgSubjectIssue, NewCommitMessage("feat", "", "something", "", "123", "breaks"), "feat: something (#123)", "", "BREAKING CHANGE: breaks"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -438,3 +869,78 @@ func Test_parseSubjectMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCommitMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    CommitMessage
+		wantErr bool
+	}{
+		{"simple message", "feat: something awesome", CommitMessage{Type: "feat", Description: "something awesome", Metadata: map[string]string{}}, false},
+		{"message with scope and body", "fix(scope): something\n\nsome body", CommitMessage{Type: "fix", Scope: "scope", Description: "something", Body: "some body", Metadata: map[string]string{}}, false},
+		{"message with footers", "feat!: something new\n\nbody\n\nissue: #12\nBREAKING CHANGE: breaks", CommitMessage{Type: "feat", Description: "something new", Body: "body\n\nissue: #12\nBREAKING CHANGE: breaks", IsBreakingChange: true, Metadata: map[string]string{breakingChangeMetadataKey: "breaks"}}, false},
+		{"invalid header", "something awesome", CommitMessage{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommitMessage(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCommitMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommitMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommitMessage_Footers(t *testing.T) {
+	got, err := ParseCommitMessage("feat: something new\n\nbody\n\nissue: #12\nBREAKING CHANGE: breaks")
+	if err != nil {
+		t.Fatalf("ParseCommitMessage() error = %v", err)
+	}
+	want := []CommitMessageFooter{{Key: "issue", Value: "#12"}, {Key: "BREAKING CHANGE", Value: "breaks"}}
+	if footers := got.Footers(); !reflect.DeepEqual(footers, want) {
+		t.Errorf("ParseCommitMessage() footers = %v, want %v", footers, want)
+	}
+}
+
+func TestCommitMessageConfig_AllowedTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  CommitMessageConfig
+		want []string
+	}{
+		{"no denied types", CommitMessageConfig{Types: []string{"feat", "fix"}}, []string{"feat", "fix"}},
+		{"denied type listed in types", CommitMessageConfig{Types: []string{"feat", "wip", "fix"}, DeniedTypes: []string{"wip"}}, []string{"feat", "fix"}},
+		{"denied type not listed in types", CommitMessageConfig{Types: []string{"feat"}, DeniedTypes: []string{"wip"}}, []string{"feat"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.AllowedTypes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommitMessageConfig.AllowedTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitMessageConfig_BreakingChangeFooterConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  CommitMessageConfig
+		want CommitMessageFooterConfig
+	}{
+		{"default", CommitMessageConfig{}, CommitMessageFooterConfig{Key: "BREAKING CHANGE", KeySynonyms: []string{"BREAKING-CHANGE"}}},
+		{"spec synonym as key", ccfgBreakingChange, CommitMessageFooterConfig{Key: "BREAKING-CHANGE", KeySynonyms: []string{"QUEBRA", "BREAKING CHANGE"}}},
+		{"custom key", ccfgBreakingChangeCustomKey, CommitMessageFooterConfig{Key: "QUEBRA", KeySynonyms: []string{"BREAKING CHANGE", "BREAKING-CHANGE"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.BreakingChangeFooterConfig(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommitMessageConfig.BreakingChangeFooterConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
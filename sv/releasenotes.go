@@ -1,11 +1,34 @@
 package sv
 
 import (
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 )
 
+// sort options for release note section items.
+const (
+	SortByDate    = "date"
+	SortByScope   = "scope"
+	SortBySubject = "subject"
+)
+
+// date source options for unreleased versions release notes.
+const (
+	DateSourceNow            = "now"
+	DateSourceLastCommitDate = "last-commit-date"
+)
+
+// UnmatchedSectionKey release note section key for commits that don't follow conventional commits, it can't be used as commit type.
+const UnmatchedSectionKey = "other"
+
+const unmatchedSectionHeader = "Other Changes"
+
+var revertedHashRegex = regexp.MustCompile(`(?i)\breverts? commit ([0-9a-f]{4,40})\b`)
+
 // ReleaseNoteProcessor release note processor interface.
 type ReleaseNoteProcessor interface {
 	Create(version *semver.Version, date time.Time, commits []GitCommitLog) ReleaseNote
@@ -25,14 +48,25 @@ func NewReleaseNoteProcessor(cfg ReleaseNotesConfig) *ReleaseNoteProcessorImpl {
 func (p ReleaseNoteProcessorImpl) Create(version *semver.Version, date time.Time, commits []GitCommitLog) ReleaseNote {
 	sections := make(map[string]ReleaseNoteSection)
 	var breakingChanges []string
+	if p.cfg.HideReverted {
+		commits = withoutReverted(commits)
+	}
 	for _, commit := range commits {
-		if name, exists := p.cfg.Headers[commit.Message.Type]; exists {
+		commit = p.overrideDescription(commit)
+		if name, exists := p.cfg.Headers[commit.Message.Type]; exists && commit.Message.Type != UnmatchedSectionKey {
 			section, sexists := sections[commit.Message.Type]
 			if !sexists {
 				section = ReleaseNoteSection{Name: name}
 			}
-			section.Items = append(section.Items, commit)
+			section.Items = p.appendItem(section.Items, commit)
 			sections[commit.Message.Type] = section
+		} else if p.cfg.IncludeUnmatched && commit.Message.Type == "" {
+			section, sexists := sections[UnmatchedSectionKey]
+			if !sexists {
+				section = ReleaseNoteSection{Name: p.unmatchedHeader()}
+			}
+			section.Items = p.appendItem(section.Items, commit)
+			sections[UnmatchedSectionKey] = section
 		}
 		if commit.Message.BreakingMessage() != "" {
 			// TODO: if no message found, should use description instead?
@@ -40,29 +74,143 @@ func (p ReleaseNoteProcessorImpl) Create(version *semver.Version, date time.Time
 		}
 	}
 
+	for key, section := range sections {
+		sortItems(section.Items, p.cfg.SortBy)
+		sections[key] = section
+	}
+
 	var breakingChangeSection BreakingChangeSection
 	if name, exists := p.cfg.Headers[breakingChangeMetadataKey]; exists && len(breakingChanges) > 0 {
 		breakingChangeSection = BreakingChangeSection{Name: name, Messages: breakingChanges}
 	}
-	return ReleaseNote{Version: version, Date: date.Truncate(time.Minute), Sections: sections, BreakingChanges: breakingChangeSection}
+
+	var summary *ReleaseNoteSummary
+	if p.cfg.ShowSummary {
+		summary = newReleaseNoteSummary(commits)
+	}
+	return ReleaseNote{Version: version, Date: date.Truncate(time.Minute), Sections: sections, BreakingChanges: breakingChangeSection, Summary: summary}
+}
+
+// overrideDescription replace commit description by the configured trailer value, if present.
+func (p ReleaseNoteProcessorImpl) overrideDescription(commit GitCommitLog) GitCommitLog {
+	if p.cfg.DescriptionTrailer == "" {
+		return commit
+	}
+	for _, footer := range commit.Message.Footers() {
+		if strings.EqualFold(footer.Key, p.cfg.DescriptionTrailer) && strings.TrimSpace(footer.Value) != "" {
+			commit.Message.Description = strings.Join(strings.Fields(footer.Value), " ")
+			return commit
+		}
+	}
+	return commit
+}
+
+// withoutReverted remove revert commits and the commits they revert, when both are on the list, reverts of commits outside of list are kept.
+// Reverted commit is identified by the hash referenced on revert body, eg.: "This reverts commit a1b2c3d.", whatever the revert commit type.
+func withoutReverted(commits []GitCommitLog) []GitCommitLog {
+	hidden := make(map[int]bool)
+	for i, commit := range commits {
+		for _, match := range revertedHashRegex.FindAllStringSubmatch(commit.Message.Body, -1) {
+			for j, original := range commits {
+				if j != i && original.Hash != "" && !hidden[j] && sameHash(original.Hash, match[1]) {
+					hidden[i], hidden[j] = true, true
+					break
+				}
+			}
+		}
+	}
+	if len(hidden) == 0 {
+		return commits
+	}
+
+	var result []GitCommitLog
+	for i, commit := range commits {
+		if !hidden[i] {
+			result = append(result, commit)
+		}
+	}
+	return result
+}
+
+// sameHash check if hashes refer to the same commit, allowing different abbreviation sizes.
+func sameHash(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// unmatchedHeader header used for commits that don't follow conventional commits, configurable using "other" header.
+func (p ReleaseNoteProcessorImpl) unmatchedHeader() string {
+	if name, exists := p.cfg.Headers[UnmatchedSectionKey]; exists && name != "" {
+		return name
+	}
+	return unmatchedSectionHeader
+}
+
+func newReleaseNoteSummary(commits []GitCommitLog) *ReleaseNoteSummary {
+	var contributors []string
+	for _, commit := range commits {
+		if commit.AuthorName != "" && !contains(commit.AuthorName, contributors) {
+			contributors = append(contributors, commit.AuthorName)
+		}
+	}
+	return &ReleaseNoteSummary{Commits: len(commits), Contributors: contributors}
+}
+
+// sortItems sort items by the given option, git log order is kept if sortBy is empty or unknown.
+func sortItems(items []GitCommitLog, sortBy string) {
+	switch sortBy {
+	case SortByDate:
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Date > items[j].Date })
+	case SortByScope:
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].Message.Scope != items[j].Message.Scope {
+				return items[i].Message.Scope < items[j].Message.Scope
+			}
+			return items[i].Message.Description < items[j].Message.Description
+		})
+	case SortBySubject:
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Message.Description < items[j].Message.Description })
+	}
+}
+
+func (p ReleaseNoteProcessorImpl) appendItem(items []GitCommitLog, commit GitCommitLog) []GitCommitLog {
+	if p.cfg.SquashDuplicates {
+		for i, item := range items {
+			if item.Message.Type == commit.Message.Type && item.Message.Scope == commit.Message.Scope && item.Message.Description == commit.Message.Description {
+				items[i].SquashedHashes = append(item.SquashedHashes, commit.Hash)
+				return items
+			}
+		}
+	}
+	return append(items, commit)
 }
 
 // ReleaseNote release note.
 type ReleaseNote struct {
 	Version         *semver.Version
 	Date            time.Time
+	TagMessage      string
+	Milestone       string
+	Label           string
 	Sections        map[string]ReleaseNoteSection
 	BreakingChanges BreakingChangeSection
+	Summary         *ReleaseNoteSummary
+}
+
+// ReleaseNoteSummary commits and contributors summary.
+type ReleaseNoteSummary struct {
+	Commits      int      `json:"commits"`
+	Contributors []string `json:"contributors"`
 }
 
 // BreakingChangeSection breaking change section
 type BreakingChangeSection struct {
-	Name     string
-	Messages []string
+	Name     string   `json:"name,omitempty"`
+	Messages []string `json:"messages,omitempty"`
 }
 
 // ReleaseNoteSection release note section.
 type ReleaseNoteSection struct {
-	Name  string
-	Items []GitCommitLog
+	Name  string         `json:"name"`
+	Items []GitCommitLog `json:"items"`
 }
@@ -49,3 +49,169 @@ func TestReleaseNoteProcessorImpl_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestReleaseNoteProcessorImpl_Create_SquashDuplicates(t *testing.T) {
+	date := time.Now()
+	commit := func(hash, scope, description string, squashed ...string) GitCommitLog {
+		return GitCommitLog{Hash: hash, Message: CommitMessage{Type: "t1", Scope: scope, Description: description, Metadata: map[string]string{}}, SquashedHashes: squashed}
+	}
+
+	tests := []struct {
+		name    string
+		squash  bool
+		commits []GitCommitLog
+		want    []GitCommitLog
+	}{
+		{"squash duplicates", true, []GitCommitLog{commit("a", "", "desc"), commit("b", "", "desc")}, []GitCommitLog{commit("a", "", "desc", "b")}},
+		{"keep different scopes", true, []GitCommitLog{commit("a", "s1", "desc"), commit("b", "s2", "desc")}, []GitCommitLog{commit("a", "s1", "desc"), commit("b", "s2", "desc")}},
+		{"squash disabled", false, []GitCommitLog{commit("a", "", "desc"), commit("b", "", "desc")}, []GitCommitLog{commit("a", "", "desc"), commit("b", "", "desc")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Headers: map[string]string{"t1": "Tag 1"}, SquashDuplicates: tt.squash})
+			if got := p.Create(nil, date, tt.commits); !reflect.DeepEqual(got.Sections["t1"].Items, tt.want) {
+				t.Errorf("ReleaseNoteProcessorImpl.Create() items = %v, want %v", got.Sections["t1"].Items, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNoteProcessorImpl_Create_SortBy(t *testing.T) {
+	date := time.Now()
+	commit := func(date, scope, description string) GitCommitLog {
+		return GitCommitLog{Date: date, Message: CommitMessage{Type: "t1", Scope: scope, Description: description, Metadata: map[string]string{}}}
+	}
+	commits := []GitCommitLog{commit("2021-01-02", "b", "b desc"), commit("2021-01-01", "a", "c desc"), commit("2021-01-03", "a", "a desc")}
+
+	tests := []struct {
+		name   string
+		sortBy string
+		want   []GitCommitLog
+	}{
+		{"default", "", []GitCommitLog{commit("2021-01-02", "b", "b desc"), commit("2021-01-01", "a", "c desc"), commit("2021-01-03", "a", "a desc")}},
+		{"unknown", "invalid", []GitCommitLog{commit("2021-01-02", "b", "b desc"), commit("2021-01-01", "a", "c desc"), commit("2021-01-03", "a", "a desc")}},
+		{"date", SortByDate, []GitCommitLog{commit("2021-01-03", "a", "a desc"), commit("2021-01-02", "b", "b desc"), commit("2021-01-01", "a", "c desc")}},
+		{"scope", SortByScope, []GitCommitLog{commit("2021-01-03", "a", "a desc"), commit("2021-01-01", "a", "c desc"), commit("2021-01-02", "b", "b desc")}},
+		{"subject", SortBySubject, []GitCommitLog{commit("2021-01-03", "a", "a desc"), commit("2021-01-02", "b", "b desc"), commit("2021-01-01", "a", "c desc")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]GitCommitLog{}, commits...)
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Headers: map[string]string{"t1": "Tag 1"}, SortBy: tt.sortBy})
+			if got := p.Create(nil, date, input); !reflect.DeepEqual(got.Sections["t1"].Items, tt.want) {
+				t.Errorf("ReleaseNoteProcessorImpl.Create() items = %v, want %v", got.Sections["t1"].Items, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNoteProcessorImpl_Create_Summary(t *testing.T) {
+	commit := func(author string) GitCommitLog {
+		return GitCommitLog{AuthorName: author, Message: CommitMessage{Type: "t1", Metadata: map[string]string{}}}
+	}
+
+	tests := []struct {
+		name    string
+		show    bool
+		commits []GitCommitLog
+		want    *ReleaseNoteSummary
+	}{
+		{"distinct contributors", true, []GitCommitLog{commit("a"), commit("b"), commit("a")}, &ReleaseNoteSummary{Commits: 3, Contributors: []string{"a", "b"}}},
+		{"no commits", true, []GitCommitLog{}, &ReleaseNoteSummary{Commits: 0}},
+		{"summary disabled", false, []GitCommitLog{commit("a")}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Headers: map[string]string{"t1": "Tag 1"}, ShowSummary: tt.show})
+			if got := p.Create(nil, time.Now(), tt.commits); !reflect.DeepEqual(got.Summary, tt.want) {
+				t.Errorf("ReleaseNoteProcessorImpl.Create() summary = %v, want %v", got.Summary, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNoteProcessorImpl_Create_IncludeUnmatched(t *testing.T) {
+	commit := func(ctype, description string) GitCommitLog {
+		return GitCommitLog{Message: CommitMessage{Type: ctype, Description: description, Metadata: map[string]string{}}}
+	}
+	commits := []GitCommitLog{commit("t1", "mapped"), commit("", "Update readme"), commit("unmapped", "unmapped type"), commit("other", "reserved type")}
+
+	tests := []struct {
+		name    string
+		include bool
+		headers map[string]string
+		want    map[string]ReleaseNoteSection
+	}{
+		{"unmatched disabled", false, map[string]string{"t1": "Tag 1"}, map[string]ReleaseNoteSection{"t1": newReleaseNoteSection("Tag 1", []GitCommitLog{commit("t1", "mapped")})}},
+		{"default header", true, map[string]string{"t1": "Tag 1"}, map[string]ReleaseNoteSection{"t1": newReleaseNoteSection("Tag 1", []GitCommitLog{commit("t1", "mapped")}), "other": newReleaseNoteSection("Other Changes", []GitCommitLog{commit("", "Update readme")})}},
+		{"custom header", true, map[string]string{"t1": "Tag 1", "other": "Misc"}, map[string]ReleaseNoteSection{"t1": newReleaseNoteSection("Tag 1", []GitCommitLog{commit("t1", "mapped")}), "other": newReleaseNoteSection("Misc", []GitCommitLog{commit("", "Update readme")})}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Headers: tt.headers, IncludeUnmatched: tt.include})
+			if got := p.Create(nil, time.Now(), commits); !reflect.DeepEqual(got.Sections, tt.want) {
+				t.Errorf("ReleaseNoteProcessorImpl.Create() sections = %v, want %v", got.Sections, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNoteProcessorImpl_Create_DescriptionTrailer(t *testing.T) {
+	commit := func(description, body string) GitCommitLog {
+		return GitCommitLog{Message: CommitMessage{Type: "t1", Description: description, Body: body, Metadata: map[string]string{}}}
+	}
+
+	tests := []struct {
+		name    string
+		trailer string
+		commit  GitCommitLog
+		want    string
+	}{
+		{"trailer disabled", "", commit("terse subject", "Changelog: user facing text"), "terse subject"},
+		{"without trailer", "Changelog", commit("terse subject", "some body"), "terse subject"},
+		{"with trailer", "Changelog", commit("terse subject", "some body\n\nChangelog: user facing text"), "user facing text"},
+		{"case insensitive trailer", "Changelog", commit("terse subject", "changelog: user facing text"), "user facing text"},
+		{"multi line trailer", "Changelog", commit("terse subject", "Changelog: user facing\n  text\nRefs: #1"), "user facing text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Headers: map[string]string{"t1": "Tag 1"}, DescriptionTrailer: tt.trailer})
+			if got := p.Create(nil, time.Now(), []GitCommitLog{tt.commit}).Sections["t1"].Items[0].Message.Description; got != tt.want {
+				t.Errorf("ReleaseNoteProcessorImpl.Create() description = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNoteProcessorImpl_Create_HideReverted(t *testing.T) {
+	commit := func(hash, ctype, body string) GitCommitLog {
+		return GitCommitLog{Hash: hash, Message: CommitMessage{Type: ctype, Description: "desc " + hash, Body: body, Metadata: map[string]string{}}}
+	}
+	feat := commit("a1b2c3d", "t1", "")
+	revert := commit("e4f5a6b", "revert", "This reverts commit a1b2c3d4e5f6a7b8c9d0a1b2c3d4e5f6a7b8c9d0.")
+	other := commit("c7d8e9f", "t1", "")
+
+	tests := []struct {
+		name    string
+		hide    bool
+		commits []GitCommitLog
+		want    map[string]int
+	}{
+		{"hide disabled", false, []GitCommitLog{revert, feat, other}, map[string]int{"t1": 2, "revert": 1}},
+		{"hide revert and original", true, []GitCommitLog{revert, feat, other}, map[string]int{"t1": 1, "revert": 0}},
+		{"keep revert without original", true, []GitCommitLog{revert, other}, map[string]int{"t1": 1, "revert": 1}},
+		{"hide non conventional revert", true, []GitCommitLog{commit("e4f5a6b", "", revert.Message.Body), feat, other}, map[string]int{"t1": 1}},
+		{"short reference", true, []GitCommitLog{commit("e4f5a6b", "revert", "this reverts commit A1B2."), feat}, map[string]int{"t1": 0, "revert": 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Headers: map[string]string{"t1": "Tag 1", "revert": "Reverts"}, HideReverted: tt.hide})
+			got := p.Create(nil, time.Now(), tt.commits)
+			for key, want := range tt.want {
+				if len(got.Sections[key].Items) != want {
+					t.Errorf("ReleaseNoteProcessorImpl.Create() %s items = %v, want %d", key, got.Sections[key].Items, want)
+				}
+			}
+		})
+	}
+}
@@ -24,9 +24,29 @@ func ToVersion(value string) (semver.Version, error) {
 	return *v, nil
 }
 
+// PromoteVersion remove pre-release from version without bumping it, eg.: 1.2.0-rc.3 to 1.2.0, returns false if version has no pre-release.
+func PromoteVersion(version semver.Version) (semver.Version, bool) {
+	if version.Prerelease() == "" {
+		return version, false
+	}
+	v, err := version.SetPrerelease("")
+	if err != nil {
+		return version, false
+	}
+	return v, true
+}
+
 // SemVerCommitsProcessor interface
 type SemVerCommitsProcessor interface {
 	NextVersion(version semver.Version, commits []GitCommitLog) (semver.Version, bool)
+	Classify(version semver.Version, commits []GitCommitLog) ClassifiedCommits
+}
+
+// ClassifiedCommits commits grouped by the version update they cause, commits that don't update version are ignored.
+type ClassifiedCommits struct {
+	Major []GitCommitLog
+	Minor []GitCommitLog
+	Patch []GitCommitLog
 }
 
 // SemVerCommitsProcessorImpl process versions using commit log
@@ -36,6 +56,9 @@ type SemVerCommitsProcessorImpl struct {
 	PatchVersionTypes         map[string]struct{}
 	KnownTypes                []string
 	IncludeUnknownTypeAsPatch bool
+	CancelReverted            bool
+	IgnoreBreakingChange      bool
+	InitialDevelopment        bool
 }
 
 // NewSemVerCommitsProcessor SemanticVersionCommitsProcessorImpl constructor
@@ -46,14 +69,17 @@ func NewSemVerCommitsProcessor(vcfg VersioningConfig, mcfg CommitMessageConfig)
 		MinorVersionTypes:         toMap(vcfg.UpdateMinor),
 		PatchVersionTypes:         toMap(vcfg.UpdatePatch),
 		KnownTypes:                mcfg.Types,
+		CancelReverted:            vcfg.CancelReverted,
+		IgnoreBreakingChange:      vcfg.IgnoreBreakingChange,
+		InitialDevelopment:        vcfg.InitialDevelopment,
 	}
 }
 
-// NextVersion calculates next version based on commit log
+// NextVersion calculates next version based on commit log, on initial development (0.x) updates are one level lower if enabled.
 func (p SemVerCommitsProcessorImpl) NextVersion(version semver.Version, commits []GitCommitLog) (semver.Version, bool) {
 	var versionToUpdate = none
-	for _, commit := range commits {
-		if v := p.versionTypeToUpdate(commit); v > versionToUpdate {
+	for _, commit := range p.filterReverted(commits) {
+		if v := p.versionTypeToUpdate(version, commit); v > versionToUpdate {
 			versionToUpdate = v
 		}
 	}
@@ -70,8 +96,33 @@ func (p SemVerCommitsProcessorImpl) NextVersion(version semver.Version, commits
 	}
 }
 
-func (p SemVerCommitsProcessorImpl) versionTypeToUpdate(commit GitCommitLog) versionType {
-	if commit.Message.IsBreakingChange {
+// Classify group commits by the version update they cause on version, using the same rules as NextVersion
+func (p SemVerCommitsProcessorImpl) Classify(version semver.Version, commits []GitCommitLog) ClassifiedCommits {
+	var result ClassifiedCommits
+	for _, commit := range p.filterReverted(commits) {
+		switch p.versionTypeToUpdate(version, commit) {
+		case major:
+			result.Major = append(result.Major, commit)
+		case minor:
+			result.Minor = append(result.Minor, commit)
+		case patch:
+			result.Patch = append(result.Patch, commit)
+		}
+	}
+	return result
+}
+
+// versionTypeToUpdate version update caused by commit on version, on initial development (0.x) it's one level lower if enabled.
+func (p SemVerCommitsProcessorImpl) versionTypeToUpdate(version semver.Version, commit GitCommitLog) versionType {
+	v := p.commitVersionType(commit)
+	if p.InitialDevelopment && version.Major() == 0 && v > patch {
+		return v - 1 // 0.x: breaking changes bump minor and features bump patch
+	}
+	return v
+}
+
+func (p SemVerCommitsProcessorImpl) commitVersionType(commit GitCommitLog) versionType {
+	if commit.Message.IsBreakingChange && !p.IgnoreBreakingChange {
 		return major
 	}
 	if _, exists := p.MajorVersionTypes[commit.Message.Type]; exists {
@@ -89,6 +140,14 @@ func (p SemVerCommitsProcessorImpl) versionTypeToUpdate(commit GitCommitLog) ver
 	return none
 }
 
+// filterReverted remove revert commits and the commits reverted by them if CancelReverted is enabled.
+func (p SemVerCommitsProcessorImpl) filterReverted(commits []GitCommitLog) []GitCommitLog {
+	if !p.CancelReverted {
+		return commits
+	}
+	return withoutReverted(commits)
+}
+
 func toMap(values []string) map[string]struct{} {
 	result := make(map[string]struct{})
 	for _, v := range values {
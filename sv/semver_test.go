@@ -39,6 +39,115 @@ func TestSemVerCommitsProcessorImpl_NextVersion(t *testing.T) {
 	}
 }
 
+func TestSemVerCommitsProcessorImpl_NextVersion_IgnoreBreakingChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		ignore  bool
+		commits []GitCommitLog
+		want    semver.Version
+	}{
+		{"breaking change bumps major", false, []GitCommitLog{commitlog("patch", map[string]string{"breaking-change": "break"})}, version("1.0.0")},
+		{"breaking change on patch type", true, []GitCommitLog{commitlog("patch", map[string]string{"breaking-change": "break"})}, version("0.0.1")},
+		{"breaking change on minor type", true, []GitCommitLog{commitlog("patch", map[string]string{}), commitlog("minor", map[string]string{"breaking-change": "break"})}, version("0.1.0")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSemVerCommitsProcessor(VersioningConfig{UpdateMinor: []string{"minor"}, UpdatePatch: []string{"patch"}, IgnoreBreakingChange: tt.ignore}, CommitMessageConfig{Types: []string{"minor", "patch"}})
+			if got, _ := p.NextVersion(version("0.0.0"), tt.commits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SemVerCommitsProcessorImpl.NextVersion() Version = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitsProcessorImpl_NextVersion_InitialDevelopment(t *testing.T) {
+	breaking := commitlog("patch", map[string]string{"breaking-change": "break"})
+	tests := []struct {
+		name    string
+		enabled bool
+		version semver.Version
+		commits []GitCommitLog
+		want    semver.Version
+	}{
+		{"disabled", false, version("0.1.0"), []GitCommitLog{breaking}, version("1.0.0")},
+		{"breaking change bumps minor", true, version("0.1.0"), []GitCommitLog{breaking}, version("0.2.0")},
+		{"feature bumps patch", true, version("0.1.0"), []GitCommitLog{commitlog("minor", map[string]string{})}, version("0.1.1")},
+		{"fix bumps patch", true, version("0.1.0"), []GitCommitLog{commitlog("patch", map[string]string{})}, version("0.1.1")},
+		{"stable version", true, version("1.1.0"), []GitCommitLog{breaking}, version("2.0.0")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSemVerCommitsProcessor(VersioningConfig{UpdateMinor: []string{"minor"}, UpdatePatch: []string{"patch"}, InitialDevelopment: tt.enabled}, CommitMessageConfig{Types: []string{"minor", "patch"}})
+			if got, _ := p.NextVersion(tt.version, tt.commits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SemVerCommitsProcessorImpl.NextVersion() Version = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitsProcessorImpl_NextVersion_CancelReverted(t *testing.T) {
+	commit := func(hash, ctype, body string) GitCommitLog {
+		return GitCommitLog{Hash: hash, Message: CommitMessage{Type: ctype, Body: body, Metadata: map[string]string{}}}
+	}
+	feature := commit("a1b2c3d", "minor", "")
+	revert := commit("e4f5a6b", "patch", "This reverts commit a1b2c3d4e5f60718293a4b5c6d7e8f9012345678.")
+	revertOld := commit("e4f5a6b", "patch", "This reverts commit 0000000000000000000000000000000000000000.")
+
+	tests := []struct {
+		name        string
+		cancel      bool
+		commits     []GitCommitLog
+		want        semver.Version
+		wantUpdated bool
+	}{
+		{"cancel disabled", false, []GitCommitLog{revert, feature}, version("0.1.0"), true},
+		{"cancel reverted commit", true, []GitCommitLog{revert, feature}, version("0.0.0"), false},
+		{"keep other commits", true, []GitCommitLog{revert, commit("f7a8b9c", "patch", ""), feature}, version("0.0.1"), true},
+		{"revert from previous version", true, []GitCommitLog{revertOld, feature}, version("0.1.0"), true},
+		{"cancel short reference", true, []GitCommitLog{commit("e4f5a6b", "patch", "This reverts commit A1B2."), feature}, version("0.0.0"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSemVerCommitsProcessor(VersioningConfig{UpdateMinor: []string{"minor"}, UpdatePatch: []string{"patch"}, CancelReverted: tt.cancel}, CommitMessageConfig{Types: []string{"minor", "patch"}})
+			got, gotUpdated := p.NextVersion(version("0.0.0"), tt.commits)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SemVerCommitsProcessorImpl.NextVersion() Version = %v, want %v", got, tt.want)
+			}
+			if tt.wantUpdated != gotUpdated {
+				t.Errorf("SemVerCommitsProcessorImpl.NextVersion() Updated = %v, want %v", gotUpdated, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitsProcessorImpl_Classify(t *testing.T) {
+	breaking := commitlog("patch", map[string]string{"breaking-change": "break"})
+	tests := []struct {
+		name               string
+		ignoreUnknown      bool
+		initialDevelopment bool
+		version            semver.Version
+		commits            []GitCommitLog
+		want               ClassifiedCommits
+	}{
+		{"no commits", false, false, version("1.0.0"), []GitCommitLog{}, ClassifiedCommits{}},
+		{"ignore unmapped known type", false, false, version("1.0.0"), []GitCommitLog{commitlog("none", map[string]string{})}, ClassifiedCommits{}},
+		{"unknown type as patch", false, false, version("1.0.0"), []GitCommitLog{commitlog("a", map[string]string{})}, ClassifiedCommits{Patch: []GitCommitLog{commitlog("a", map[string]string{})}}},
+		{"ignore unknown type", true, false, version("1.0.0"), []GitCommitLog{commitlog("a", map[string]string{})}, ClassifiedCommits{}},
+		{"group by version type", false, false, version("1.0.0"), []GitCommitLog{commitlog("patch", map[string]string{}), commitlog("minor", map[string]string{}), commitlog("major", map[string]string{}), breaking}, ClassifiedCommits{Major: []GitCommitLog{commitlog("major", map[string]string{}), breaking}, Minor: []GitCommitLog{commitlog("minor", map[string]string{})}, Patch: []GitCommitLog{commitlog("patch", map[string]string{})}}},
+		{"initial development on 0.x", false, true, version("0.2.0"), []GitCommitLog{commitlog("patch", map[string]string{}), commitlog("minor", map[string]string{}), breaking}, ClassifiedCommits{Minor: []GitCommitLog{breaking}, Patch: []GitCommitLog{commitlog("patch", map[string]string{}), commitlog("minor", map[string]string{})}}},
+		{"initial development after 1.0.0", false, true, version("1.0.0"), []GitCommitLog{commitlog("minor", map[string]string{}), breaking}, ClassifiedCommits{Major: []GitCommitLog{breaking}, Minor: []GitCommitLog{commitlog("minor", map[string]string{})}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSemVerCommitsProcessor(VersioningConfig{UpdateMajor: []string{"major"}, UpdateMinor: []string{"minor"}, UpdatePatch: []string{"patch"}, IgnoreUnknown: tt.ignoreUnknown, InitialDevelopment: tt.initialDevelopment}, CommitMessageConfig{Types: []string{"major", "minor", "patch", "none"}})
+			if got := p.Classify(tt.version, tt.commits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SemVerCommitsProcessorImpl.Classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestToVersion(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -63,3 +172,27 @@ func TestToVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestPromoteVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     semver.Version
+		want        semver.Version
+		wantUpdated bool
+	}{
+		{"release candidate", version("1.2.0-rc.3"), version("1.2.0"), true},
+		{"prerelease with metadata", version("1.2.0-beta+build.1"), version("1.2.0+build.1"), true},
+		{"no prerelease", version("1.2.0"), version("1.2.0"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotUpdated := PromoteVersion(tt.version)
+			if !got.Equal(&tt.want) || got.Metadata() != tt.want.Metadata() {
+				t.Errorf("PromoteVersion() version = %v, want %v", got, tt.want)
+			}
+			if gotUpdated != tt.wantUpdated {
+				t.Errorf("PromoteVersion() updated = %v, want %v", gotUpdated, tt.wantUpdated)
+			}
+		})
+	}
+}